@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/capture"
+)
+
+func commandBag(ctx context.Context, cfg *config, args ...string) error {
+	if len(saveData.Bag) == 0 {
+		return fmt.Errorf("Your bag is empty.")
+	}
+	names := make([]string, 0, len(saveData.Bag))
+	for name := range saveData.Bag {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("Bag:")
+	for _, name := range names {
+		item, err := client.GetItem(ctx, name)
+		if err != nil {
+			// Eşya bilgisi gelmese de adetleri gösterelim
+			fmt.Printf("  - %s x%d\n", name, saveData.Bag[name])
+			continue
+		}
+		fmt.Printf("  - %s x%d", item.EnglishName(), saveData.Bag[name])
+		if item.Sprites.Default != "" {
+			fmt.Printf(" (%s)", item.Sprites.Default)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// chooseBall çantada top varsa bilgisini ve çarpanını döner, adedi azaltmaz
+func chooseBall(ctx context.Context, name string) (string, float64, error) {
+	if saveData.Bag[name] <= 0 {
+		return "", 0, fmt.Errorf("You have no %s left.", name)
+	}
+	item, err := client.GetItem(ctx, name)
+	if err != nil {
+		return "", 0, err
+	}
+	if !item.IsBall() {
+		return "", 0, fmt.Errorf("%s is not a Poke Ball.", item.EnglishName())
+	}
+	modifier, _ := capture.BallModifier(name)
+	return item.EnglishName(), modifier, nil
+}
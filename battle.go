@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/battle"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+)
+
+func statsMap(s battle.Stats) map[string]int {
+	m := make(map[string]int, len(s))
+	for i, v := range s {
+		m[battle.Stat(i).String()] = v
+	}
+	return m
+}
+
+func statsFromMap(m map[string]int) battle.Stats {
+	var s battle.Stats
+	for name, v := range m {
+		if stat, ok := battle.ParseStat(name); ok {
+			s[stat] = v
+		}
+	}
+	return s
+}
+
+func baseStats(p pokeapi.Pokemon) battle.Stats {
+	var s battle.Stats
+	for _, stat := range p.Stats {
+		if i, ok := battle.ParseStat(stat.Stat.Name); ok {
+			s[i] = stat.BaseStat
+		}
+	}
+	return s
+}
+
+func effortYield(p pokeapi.Pokemon) battle.Stats {
+	var s battle.Stats
+	for _, stat := range p.Stats {
+		if i, ok := battle.ParseStat(stat.Stat.Name); ok {
+			s[i] = stat.Effort
+		}
+	}
+	return s
+}
+
+// battleMoves oyunlardaki gibi o seviyeye kadar en son öğrenilen dört hasar veren hareketi seçer
+func battleMoves(ctx context.Context, p pokeapi.Pokemon, level int, group string) ([]battle.Move, error) {
+	if group == "" {
+		group = defaultMoveGroup(p)
+	}
+	learned := learnset(p, group)
+	var moves []battle.Move
+	seen := make(map[string]bool)
+	for i := len(learned) - 1; i >= 0 && len(moves) < 4; i-- {
+		lm := learned[i]
+		if lm.Method != "level-up" || lm.Level > level || seen[lm.Name] {
+			continue
+		}
+		seen[lm.Name] = true
+		move, err := client.GetMove(ctx, lm.Name)
+		if err != nil {
+			return nil, err
+		}
+		if move.Power == 0 || move.DamageClass.Name == "status" {
+			continue
+		}
+		moves = append(moves, battle.Move{
+			Name:     move.Name,
+			Type:     move.Type.Name,
+			Class:    move.DamageClass.Name,
+			Power:    move.Power,
+			Accuracy: move.Accuracy,
+			Priority: move.Priority,
+		})
+	}
+	return moves, nil
+}
+
+// newCombatant savaş başlamadan gereken her şeyi indirir, savaş döngüsü ağa çıkmaz
+func newCombatant(ctx context.Context, name string, level int, ivs, evs battle.Stats, group string) (*battle.Combatant, pokeapi.Pokemon, error) {
+	p, err := client.GetPokemon(ctx, name)
+	if err != nil {
+		return nil, p, err
+	}
+	moves, err := battleMoves(ctx, p, level, group)
+	if err != nil {
+		return nil, p, err
+	}
+	var types []string
+	for _, t := range p.Types {
+		types = append(types, t.Type.Name)
+	}
+	needed := append([]string{}, types...)
+	for _, m := range moves {
+		needed = append(needed, m.Type)
+	}
+	if err := loadTypes(ctx, needed...); err != nil {
+		return nil, p, err
+	}
+	return battle.NewCombatant(p.Name, level, types, baseStats(p), ivs, evs, moves), p, nil
+}
+
+type battleOutcome int
+
+const (
+	battleWon battleOutcome = iota
+	battleLost
+	battleLeft
+	battleRan
+)
+
+// readBattleInput savaş sırasında REPL'den satır okur, testlerde değiştirilebilir
+var readBattleInput = func(prompt string) (string, error) {
+	if rl == nil {
+		return "", io.EOF
+	}
+	rl.SetPrompt(prompt)
+	defer rl.SetPrompt(replPrompt)
+	return rl.Readline()
+}
+
+func commandBattle(ctx context.Context, cfg *config, args ...string) error {
+	if wild == nil {
+		return fmt.Errorf("There is no wild pokemon to battle. Use encounter to find one.")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage: battle <pokemon>")
+	}
+	pokemonName := strings.ToLower(args[0])
+	caught, ok := saveData.Caught[pokemonName]
+	if !ok {
+		return fmt.Errorf("you have not caught that pokemon")
+	}
+
+	v, err := currentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	group := ""
+	if v != nil {
+		group = v.Group
+	}
+	player, _, err := newCombatant(ctx, pokemonName, caught.Level, statsFromMap(caught.IVs), statsFromMap(caught.EVs), group)
+	if err != nil {
+		return err
+	}
+	opponent, opponentData, err := newCombatant(ctx, wild.Pokemon, wild.Level, wild.IVs, battle.Stats{}, group)
+	if err != nil {
+		return err
+	}
+	// Önceki savaşta aldığı hasar kalıyor
+	opponent.HP = max(1, int(math.Ceil(float64(opponent.MaxHP())*wild.HPFraction)))
+
+	b := battle.New(player, opponent, typeChart, rng)
+	fmt.Printf("Go, %s! The wild %s (Lv. %d) is ready to fight.\n", player.Name, opponent.Name, opponent.Level)
+	switch runBattle(b, readBattleInput) {
+	case battleWon:
+		fmt.Printf("The wild %s fainted! %s won the battle.\n", opponent.Name, player.Name)
+		caught.EVs = statsMap(battle.GainEVs(statsFromMap(caught.EVs), effortYield(opponentData)))
+		saveData.Caught[pokemonName] = caught
+		wild = nil
+		return persist()
+	case battleLost:
+		fmt.Printf("%s fainted! The wild %s got away.\n", player.Name, opponent.Name)
+		wild = nil
+	case battleLeft:
+		wild.HPFraction = float64(opponent.HP) / float64(opponent.MaxHP())
+		fmt.Printf("%s came back. The wild %s has %d/%d HP, try: catch %s [ball]\n", player.Name, opponent.Name, opponent.HP, opponent.MaxHP(), opponent.Name)
+	case battleRan:
+		fmt.Printf("Got away safely from %s!\n", opponent.Name)
+		wild = nil
+	}
+	return nil
+}
+
+// runBattle biri bayılana ya da oyuncu ayrılana kadar turları oynatır
+func runBattle(b *battle.Battle, read func(prompt string) (string, error)) battleOutcome {
+	for !b.Over() {
+		fmt.Printf("%s Lv. %d HP %d/%d  vs  wild %s Lv. %d HP %d/%d\n",
+			b.Player.Name, b.Player.Level, b.Player.HP, b.Player.MaxHP(),
+			b.Opponent.Name, b.Opponent.Level, b.Opponent.HP, b.Opponent.MaxHP())
+		for i, m := range b.Player.Moves {
+			fmt.Printf("  %d) %s (%s, power %d)\n", i+1, m.Name, moveTypeLabel(m), m.Power)
+		}
+		fmt.Println("  catch) return and throw a ball   run) flee")
+
+		line, err := read("Battle > ")
+		if err != nil { // Ctrl+C ya da Ctrl+D savaştan kaçar
+			return battleRan
+		}
+		choice := strings.ToLower(strings.TrimSpace(line))
+		switch choice {
+		case "catch":
+			return battleLeft
+		case "run":
+			return battleRan
+		}
+		index := -1
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(b.Player.Moves) {
+			index = n - 1
+		}
+		for i, m := range b.Player.Moves {
+			if m.Name == choice {
+				index = i
+			}
+		}
+		if index < 0 {
+			fmt.Println("Pick a move by number or name, catch or run.")
+			continue
+		}
+
+		for _, ev := range b.PlayTurn(index, b.OpponentMove()) {
+			printBattleEvent(ev)
+		}
+	}
+	if b.Opponent.Fainted() {
+		return battleWon
+	}
+	return battleLost
+}
+
+func moveTypeLabel(m battle.Move) string {
+	if m.Type == "" {
+		return "typeless"
+	}
+	return m.Type
+}
+
+func printBattleEvent(ev battle.Event) {
+	fmt.Printf("%s used %s!\n", ev.Attacker, ev.Move)
+	switch {
+	case ev.NoEffect:
+		fmt.Println("  Nothing happened.")
+		return
+	case ev.Missed:
+		fmt.Printf("  %s avoided the attack!\n", ev.Defender)
+		return
+	case ev.Effectiveness == 0:
+		fmt.Printf("  It doesn't affect %s...\n", ev.Defender)
+		return
+	}
+	if ev.Critical {
+		fmt.Println("  A critical hit!")
+	}
+	if ev.Effectiveness > 1 {
+		fmt.Println("  It's super effective!")
+	} else if ev.Effectiveness < 1 {
+		fmt.Println("  It's not very effective...")
+	}
+	fmt.Printf("  %s took %d damage.\n", ev.Defender, ev.Damage)
+	if ev.Fainted {
+		fmt.Printf("  %s fainted!\n", ev.Defender)
+	}
+}
@@ -1,24 +1,28 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"math/rand"
-	"net/http"
-	"os"
 	"time"
 
+	"github.com/ardamertdedeoglu/pokedexcli/internal/capture"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
 	"github.com/ardamertdedeoglu/pokedexcli/internal/pokecache"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/savefile"
 )
 
 var cache *pokecache.Cache
+var client *pokeapi.Client
+
+var errExit = errors.New("exit")
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config, ...string) error
-	config      config
+	callback    func(context.Context, *config, ...string) error
+	config      *config
 }
 
 type config struct {
@@ -27,10 +31,13 @@ type config struct {
 }
 
 var commands = make(map[string]cliCommand)
-var inventory = make(map[string]Pokemon)
+
+// Testlerde tekrarlanabilir sonuç için değiştirilebilir
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+var mapConfig = &config{}
 
 func init() {
-	cache = pokecache.NewCache(5 * time.Second)
 	commands["exit"] = cliCommand{
 		name:        "exit",
 		description: "Exit the Pokedex",
@@ -45,40 +52,112 @@ func init() {
 
 	commands["map"] = cliCommand{
 		name:        "map",
-		description: "Maps 20 next locations",
+		description: "Maps 20 next locations: map [regions | areas | <region> | <region>/<location>]",
 		callback:    commandMap,
-		config: config{
-			Next:     "https://pokeapi.co/api/v2/location-area/",
-			Previous: "",
-		},
+		config:      mapConfig,
 	}
 	commands["mapb"] = cliCommand{
 		name:        "mapback",
 		description: "Maps 20 previous locations",
 		callback:    commandMapBack,
-		config: config{
-			Next:     "https://pokeapi.co/api/v2/location-area/",
-			Previous: "",
-		},
+		config:      mapConfig,
 	}
 	commands["explore"] = cliCommand{
 		name:        "explore",
-		description: "Explores a locations pokemons.",
+		description: "Explores a locations pokemons, the current area if none is given.",
 		callback:    commandExplore,
 	}
 
+	commands["version"] = cliCommand{
+		name:        "version",
+		description: "Filter by game version for this session: version [name | all].",
+		callback:    commandVersion,
+	}
+
+	commands["goto"] = cliCommand{
+		name:        "goto",
+		description: "Travel to a location area: goto <area>.",
+		callback:    commandGoto,
+	}
+
+	commands["where"] = cliCommand{
+		name:        "where",
+		description: "Show where you are, the region and nearby areas.",
+		callback:    commandWhere,
+	}
+
+	commands["encounter"] = cliCommand{
+		name:        "encounter",
+		description: "Look for a wild pokemon in the current area: encounter [method].",
+		callback:    commandEncounter,
+	}
+
+	commands["flee"] = cliCommand{
+		name:        "flee",
+		description: "Run away from the wild pokemon.",
+		callback:    commandFlee,
+	}
+
 	commands["catch"] = cliCommand{
 		name:        "catch",
-		description: "Tries to catch a pokemon.",
+		description: "Tries to catch the wild pokemon: catch <pokemon> [ball].",
 		callback:    commandCatch,
 	}
 
+	commands["bag"] = cliCommand{
+		name:        "bag",
+		description: "List the items in your bag.",
+		callback:    commandBag,
+	}
+
 	commands["inspect"] = cliCommand{
 		name:        "inspect",
-		description: "Inspect an already caught pokemon",
+		description: "Inspect an already caught pokemon: inspect <pokemon> [--moves] [--abilities] [--items] [--forms] [--cries] [--games] [--all]",
 		callback:    commandInspect,
 	}
 
+	commands["lookup"] = cliCommand{
+		name:        "lookup",
+		description: "Look up any pokemon without catching it: lookup <name|id> [inspect options].",
+		callback:    commandLookup,
+	}
+
+	commands["evolution"] = cliCommand{
+		name:        "evolution",
+		description: "Show how a pokemon evolves: evolution <pokemon>.",
+		callback:    commandEvolution,
+	}
+
+	commands["evolve"] = cliCommand{
+		name:        "evolve",
+		description: "Evolve a caught pokemon when it is ready: evolve <pokemon> [evolution].",
+		callback:    commandEvolve,
+	}
+
+	commands["matchup"] = cliCommand{
+		name:        "matchup",
+		description: "Show type weaknesses and resistances: matchup <pokemon|type> [vs <pokemon|type>].",
+		callback:    commandMatchup,
+	}
+
+	commands["battle"] = cliCommand{
+		name:        "battle",
+		description: "Battle the wild pokemon with one of yours: battle <pokemon>.",
+		callback:    commandBattle,
+	}
+
+	commands["profile"] = cliCommand{
+		name:        "profile",
+		description: "Manage trainer profiles: profile list | new <name> | use <name> | delete <name>",
+		callback:    commandProfile,
+	}
+
+	commands["cache"] = cliCommand{
+		name:        "cache",
+		description: "Shows cache statistics.",
+		callback:    commandCache,
+	}
+
 	commands["pokedex"] = cliCommand{
 		name:        "pokedex",
 		description: "List the caught pokemons.",
@@ -86,13 +165,12 @@ func init() {
 	}
 }
 
-func commandExit(cfg *config, args ...string) error {
+func commandExit(ctx context.Context, cfg *config, args ...string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+	return errExit
 }
 
-func commandHelp(cfg *config, args ...string) error {
+func commandHelp(ctx context.Context, cfg *config, args ...string) error {
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage:")
 	fmt.Println()
@@ -103,586 +181,147 @@ func commandHelp(cfg *config, args ...string) error {
 	return nil
 }
 
-type location struct {
-	Count    int    `json:"count"`
-	Next     string `json:"next"`
-	Previous any    `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"results"`
+func commandMap(ctx context.Context, cfg *config, args ...string) error {
+	return browseMap(ctx, cfg, false, args...)
 }
 
-type PokemonEncounter struct {
-	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"encounter_method_rates"`
-	GameIndex int `json:"game_index"`
-	ID        int `json:"id"`
-	Location  struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
-	} `json:"names"`
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			EncounterDetails []struct {
-				Chance          int   `json:"chance"`
-				ConditionValues []any `json:"condition_values"`
-				MaxLevel        int   `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
-			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
+func commandMapBack(ctx context.Context, cfg *config, args ...string) error {
+	return browseMap(ctx, cfg, true, args...)
 }
 
-type Pokemon struct {
-	Abilities []struct {
-		Ability struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
-	} `json:"abilities"`
-	BaseExperience int `json:"base_experience"`
-	Cries          struct {
-		Latest string `json:"latest"`
-		Legacy string `json:"legacy"`
-	} `json:"cries"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
-	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
-	} `json:"game_indices"`
-	Height    int `json:"height"`
-	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
-		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"held_items"`
-	ID                     int    `json:"id"`
-	IsDefault              bool   `json:"is_default"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
-		VersionGroupDetails []struct {
-			LevelLearnedAt  int `json:"level_learned_at"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			Order        any `json:"order"`
-			VersionGroup struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
-		} `json:"version_group_details"`
-	} `json:"moves"`
-	Name          string `json:"name"`
-	Order         int    `json:"order"`
-	PastAbilities []struct {
-		Abilities []struct {
-			Ability  any  `json:"ability"`
-			IsHidden bool `json:"is_hidden"`
-			Slot     int  `json:"slot"`
-		} `json:"abilities"`
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-	} `json:"past_abilities"`
-	PastStats []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Stats []struct {
-			BaseStat int `json:"base_stat"`
-			Effort   int `json:"effort"`
-			Stat     struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"stat"`
-		} `json:"stats"`
-	} `json:"past_stats"`
-	PastTypes []any `json:"past_types"`
-	Species   struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
-	Sprites struct {
-		BackDefault      string `json:"back_default"`
-		BackFemale       string `json:"back_female"`
-		BackShiny        string `json:"back_shiny"`
-		BackShinyFemale  string `json:"back_shiny_female"`
-		FrontDefault     string `json:"front_default"`
-		FrontFemale      string `json:"front_female"`
-		FrontShiny       string `json:"front_shiny"`
-		FrontShinyFemale string `json:"front_shiny_female"`
-		Other            struct {
-			DreamWorld struct {
-				FrontDefault string `json:"front_default"`
-				FrontFemale  any    `json:"front_female"`
-			} `json:"dream_world"`
-			Home struct {
-				FrontDefault     string `json:"front_default"`
-				FrontFemale      string `json:"front_female"`
-				FrontShiny       string `json:"front_shiny"`
-				FrontShinyFemale string `json:"front_shiny_female"`
-			} `json:"home"`
-			OfficialArtwork struct {
-				FrontDefault string `json:"front_default"`
-				FrontShiny   string `json:"front_shiny"`
-			} `json:"official-artwork"`
-			Showdown struct {
-				BackDefault      string `json:"back_default"`
-				BackFemale       string `json:"back_female"`
-				BackShiny        string `json:"back_shiny"`
-				BackShinyFemale  any    `json:"back_shiny_female"`
-				FrontDefault     string `json:"front_default"`
-				FrontFemale      string `json:"front_female"`
-				FrontShiny       string `json:"front_shiny"`
-				FrontShinyFemale string `json:"front_shiny_female"`
-			} `json:"showdown"`
-		} `json:"other"`
-		Versions struct {
-			GenerationI struct {
-				RedBlue struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"red-blue"`
-				Yellow struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"yellow"`
-			} `json:"generation-i"`
-			GenerationIi struct {
-				Crystal struct {
-					BackDefault           string `json:"back_default"`
-					BackShiny             string `json:"back_shiny"`
-					BackShinyTransparent  string `json:"back_shiny_transparent"`
-					BackTransparent       string `json:"back_transparent"`
-					FrontDefault          string `json:"front_default"`
-					FrontShiny            string `json:"front_shiny"`
-					FrontShinyTransparent string `json:"front_shiny_transparent"`
-					FrontTransparent      string `json:"front_transparent"`
-				} `json:"crystal"`
-				Gold struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"gold"`
-				Silver struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"silver"`
-			} `json:"generation-ii"`
-			GenerationIii struct {
-				Emerald struct {
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"emerald"`
-				FireredLeafgreen struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"firered-leafgreen"`
-				RubySapphire struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"ruby-sapphire"`
-			} `json:"generation-iii"`
-			GenerationIv struct {
-				DiamondPearl struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"diamond-pearl"`
-				HeartgoldSoulsilver struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"heartgold-soulsilver"`
-				Platinum struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"platinum"`
-			} `json:"generation-iv"`
-			GenerationIx struct {
-				ScarletViolet struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  any    `json:"front_female"`
-				} `json:"scarlet-violet"`
-			} `json:"generation-ix"`
-			GenerationV struct {
-				BlackWhite struct {
-					Animated struct {
-						BackDefault      string `json:"back_default"`
-						BackFemale       string `json:"back_female"`
-						BackShiny        string `json:"back_shiny"`
-						BackShinyFemale  string `json:"back_shiny_female"`
-						FrontDefault     string `json:"front_default"`
-						FrontFemale      string `json:"front_female"`
-						FrontShiny       string `json:"front_shiny"`
-						FrontShinyFemale string `json:"front_shiny_female"`
-					} `json:"animated"`
-					BackDefault      string `json:"back_default"`
-					BackFemale       string `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  string `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"black-white"`
-			} `json:"generation-v"`
-			GenerationVi struct {
-				OmegarubyAlphasapphire struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"omegaruby-alphasapphire"`
-				XY struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"x-y"`
-			} `json:"generation-vi"`
-			GenerationVii struct {
-				Icons struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  any    `json:"front_female"`
-				} `json:"icons"`
-				UltraSunUltraMoon struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      string `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale string `json:"front_shiny_female"`
-				} `json:"ultra-sun-ultra-moon"`
-			} `json:"generation-vii"`
-			GenerationViii struct {
-				BrilliantDiamondShiningPearl struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  any    `json:"front_female"`
-				} `json:"brilliant-diamond-shining-pearl"`
-				Icons struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  string `json:"front_female"`
-				} `json:"icons"`
-			} `json:"generation-viii"`
-		} `json:"versions"`
-	} `json:"sprites"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	Weight int `json:"weight"`
-}
-
-func commandMap(cfg *config, args ...string) error {
-	var result location
-	baseURL := cfg.Next
-
-	if res, ok := cache.Get(baseURL); ok {
-		err := json.Unmarshal(res, &result)
-		fmt.Println("command from cache")
-		if err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(baseURL)
-		fmt.Println("cache no")
-		if err != nil {
-			fmt.Printf("error getting locations")
-			return err
-		}
-		bodyData, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal(bodyData, &result)
-		if err != nil {
-			fmt.Printf("error decoding locations %s", err)
-			return err
-		}
-		cache.Add(baseURL, bodyData)
-	}
-
-	next := result.Next
-	previous := result.Previous
-	locations := result.Results
-	for _, location := range locations {
+func printLocationAreas(cfg *config, result pokeapi.LocationAreaList) {
+	for _, location := range result.Results {
 		fmt.Println(location.Name)
 	}
+	cfg.Next = result.Next
+	cfg.Previous = result.Previous
+}
 
-	if previous == nil {
-		previous = ""
+func commandExplore(ctx context.Context, cfg *config, args ...string) error {
+	cityName := saveData.Location
+	if len(args) > 0 {
+		cityName = args[0]
 	}
-	newConfig := config{
-		Next:     next,
-		Previous: cfg.Next,
+	if cityName == "" {
+		return fmt.Errorf("usage: explore [location-area], or goto an area first")
 	}
-	tmp := commands["map"]
-	tmp.config = newConfig
-	commands["map"] = tmp
-
-	return nil
-}
-
-func commandMapBack(cfg *config, args ...string) error {
-	var result location
-	baseURL := cfg.Previous
+	fmt.Printf("Exploring %s...\n", cityName)
 
-	if commands["map"].config.Next == "https://pokeapi.co/api/v2/location-area/" {
-		fmt.Println("you're on the first page")
-		return nil
+	result, err := client.GetLocationArea(ctx, cityName)
+	if err != nil {
+		return err
 	}
 
-	if res, ok := cache.Get(baseURL); ok {
-		fmt.Println("mapb cache")
-		err := json.Unmarshal(res, &result)
-		if err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(baseURL)
-		if err != nil {
-			fmt.Printf("error getting locations")
-			return err
-		}
-		bodyData, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal(bodyData, &result)
-		if err != nil {
-			fmt.Printf("error decoding locations %s", err)
-			return err
-		}
-		cache.Add(baseURL, bodyData)
+	if len(result.PokemonEncounters) == 0 {
+		return fmt.Errorf("Found no pokemon.")
 	}
 
-	next := result.Next
-	previous := result.Previous
-	locations := result.Results
-	for _, location := range locations {
-		fmt.Println(location.Name)
+	v, err := currentVersion(ctx)
+	if err != nil {
+		return err
 	}
-
-	if previous == nil {
-		previous = ""
+	names := areaPokemon(result, v)
+	for _, name := range names {
+		fmt.Println("- " + name)
 	}
-	newConfig := config{
-		Next:     next,
-		Previous: previous.(string),
+	if len(names) == 0 && v != nil {
+		return fmt.Errorf("%s doesn't exist in pokemon %s.", result.Name, v.Name)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("Found no pokemon.")
 	}
-	tmp := commands["map"]
-	tmp.config = newConfig
-	commands["map"] = tmp
 
 	return nil
 }
 
-func commandExplore(cfg *config, args ...string) error {
-	cityName := args[0]
-	fmt.Printf("Exploring %s...\n", cityName)
-	baseURL := "https://pokeapi.co/api/v2/location-area/" + cityName + "/"
-
-	var result PokemonEncounter
-
-	if res, ok := cache.Get(baseURL); ok {
-		err := json.Unmarshal(res, &result)
-		fmt.Println("command from cache")
-		if err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(baseURL)
-		fmt.Println("cache no")
-		if err != nil {
-			fmt.Printf("error getting locations")
-			return err
-		}
-		bodyData, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal(bodyData, &result)
-		if err != nil {
-			fmt.Printf("error decoding locations %s", err)
-			return err
+func commandCatch(ctx context.Context, cfg *config, args ...string) error {
+	if wild == nil {
+		return fmt.Errorf("There is no wild pokemon to catch. Use encounter to find one.")
+	}
+	pokemonName := wild.Pokemon
+	ballName := "poke-ball"
+	// "catch <pokemon> [ball]" ya da kısaca "catch [ball]"
+	if len(args) > 0 && args[0] == pokemonName {
+		args = args[1:]
+	} else if len(args) > 0 {
+		if _, isBall := capture.BallModifier(args[0]); !isBall && saveData.Bag[args[0]] == 0 {
+			return fmt.Errorf("You can only catch the wild %s right now.", pokemonName)
 		}
-		cache.Add(baseURL, bodyData)
-
 	}
-
-	if len(result.EncounterMethodRates) == 0 {
-		return fmt.Errorf("Found no pokemon.")
+	if len(args) > 0 {
+		ballName = args[0]
 	}
-
-	for _, val := range result.PokemonEncounters {
-		fmt.Println("- " + val.Pokemon.Name)
+	if _, ok := saveData.Caught[pokemonName]; ok {
+		return fmt.Errorf("You already have this pokemon.")
 	}
-
-	return nil
-}
-
-func commandCatch(cfg *config, args ...string) error {
-	pokemonName := args[0]
-	baseURL := "https://pokeapi.co/api/v2/pokemon/" + pokemonName + "/"
-	var result Pokemon
-
-	res, err := http.Get(baseURL)
+	ballLabel, ballModifier, err := chooseBall(ctx, ballName)
 	if err != nil {
-		fmt.Printf("error getting locations")
 		return err
 	}
-	bodyData, err := io.ReadAll(res.Body)
+
+	result, err := client.GetPokemon(ctx, pokemonName)
 	if err != nil {
 		return err
 	}
-	err = json.Unmarshal(bodyData, &result)
+
+	species, err := client.GetPokemonSpecies(ctx, result.Species.Name)
 	if err != nil {
-		fmt.Printf("error decoding locations %s", err)
 		return err
 	}
 
-	if _, ok := inventory[pokemonName]; ok {
-		return fmt.Errorf("You already have this pokemon.")
+	attempt := capture.Attempt{
+		CaptureRate: species.CaptureRate,
+		HPFraction:  wild.HPFraction,
+		Ball:        ballModifier,
+		Status:      wild.Status,
+	}
+	fmt.Printf("Catch chance: %.1f%%\n", capture.Probability(attempt)*100)
+	fmt.Printf("Throwing a %s at %s...\n", ballLabel, pokemonName)
+	saveData.Bag[ballName]--
+	if saveData.Bag[ballName] <= 0 {
+		delete(saveData.Bag, ballName)
+	}
+	if caught, shakes := capture.Throw(attempt, rng); caught {
+		fmt.Printf("%s was caught!\n", pokemonName)
+		saveData.Caught[pokemonName] = savefile.Caught{
+			Pokemon:  savefile.FromAPI(result),
+			CaughtAt: time.Now(),
+			Location: wild.Area,
+			Level:    wild.Level,
+			IVs:      statsMap(wild.IVs),
+		}
+		wild = nil
+		fmt.Println("You may now inspect it with the inspect command.")
+		if err := persist(); err != nil {
+			return err
+		}
 	} else {
-		fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
-		catchChance := rand.Intn(1000)
-		if result.BaseExperience < catchChance {
-			fmt.Printf("%s was caught!\n", pokemonName)
-			inventory[pokemonName] = result
-			fmt.Println("You may now inspect it with the inspect command.")
-		} else {
-			fmt.Printf("%s escaped!\n", pokemonName)
+		fmt.Printf("The ball shook %d time(s)... %s escaped!\n", shakes, pokemonName)
+		if err := persist(); err != nil {
+			return err
 		}
 	}
 	return nil
 
 }
 
-func commandInspect(cfg *config, args ...string) error {
-	pokemonName := args[0]
-	if res, ok := inventory[pokemonName]; !ok {
-		return fmt.Errorf("you have not caught that pokemon")
-	} else {
-		fmt.Printf("Name: %s\n", res.Name)
-		fmt.Printf("Height: %v\n", res.Height)
-		fmt.Printf("Weight: %v\n", res.Weight)
-		fmt.Printf("Stats:\n")
-		for _, val := range res.Stats {
-			fmt.Printf("  -%s: %v\n", val.Stat.Name, val.BaseStat)
-		}
-		fmt.Printf("Types:\n")
-		for _, val := range res.Types {
-			fmt.Printf("  - %s\n", val.Type.Name)
-		}
+func commandPokedex(ctx context.Context, cfg *config, args ...string) error {
+	if len(saveData.Caught) == 0 {
+		return fmt.Errorf("You have no pokemons.")
+	}
+	for _, val := range saveData.Caught {
+		fmt.Printf(" - %s\n", val.Pokemon.Name)
 	}
 	return nil
 }
 
-func commandPokedex(cfg *config, args ...string) error {
-	if len(inventory) == 0 {
-		return fmt.Errorf("You have no pokemons.")
-	}
-	for _, val := range inventory {
-		fmt.Printf(" - %s\n", val.Name)
+func commandCache(ctx context.Context, cfg *config, args ...string) error {
+	stats := cache.Stats()
+	fmt.Printf("Entries: %d (%d bytes)\n", stats.Entries, stats.Bytes)
+	fmt.Printf("Hits: %d, Misses: %d, Shared fetches: %d\n", stats.Hits, stats.Misses, stats.Shared)
+	fmt.Printf("In flight: %d\n", len(stats.InFlight))
+	for key, waiters := range stats.InFlight {
+		fmt.Printf("  - %s (%d waiting)\n", key, waiters)
 	}
 	return nil
 }
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/battle"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/capture"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+)
+
+type wildEncounter struct {
+	Pokemon string
+	Level   int
+	Area    string
+	Method  string
+	Version string
+	// Yakalama formülü için, savaşta değişebilir
+	HPFraction float64
+	Status     capture.Status
+	// Yakalanırsa pokemonla birlikte kaydedilir
+	IVs battle.Stats
+}
+
+// Şu an karşımızdaki vahşi pokemon, kaçana ya da yakalanana kadar sadece o yakalanabilir
+var wild *wildEncounter
+
+type encounterSlot struct {
+	pokemon  string
+	chance   int
+	minLevel int
+	maxLevel int
+}
+
+// encounterVersions bölgede karşılaşma verisi olan sürümleri, en çok slotu olan önce gelecek şekilde döner
+func encounterVersions(area pokeapi.LocationArea) []string {
+	counts := make(map[string]int)
+	for _, enc := range area.PokemonEncounters {
+		for _, vd := range enc.VersionDetails {
+			counts[vd.Version.Name] += len(vd.EncounterDetails)
+		}
+	}
+	versions := make([]string, 0, len(counts))
+	for v := range counts {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if counts[versions[i]] != counts[versions[j]] {
+			return counts[versions[i]] > counts[versions[j]]
+		}
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
+func encounterSlots(area pokeapi.LocationArea, version string) map[string][]encounterSlot {
+	slots := make(map[string][]encounterSlot)
+	for _, enc := range area.PokemonEncounters {
+		for _, vd := range enc.VersionDetails {
+			if vd.Version.Name != version {
+				continue
+			}
+			for _, detail := range vd.EncounterDetails {
+				slots[detail.Method.Name] = append(slots[detail.Method.Name], encounterSlot{
+					pokemon:  enc.Pokemon.Name,
+					chance:   detail.Chance,
+					minLevel: detail.MinLevel,
+					maxLevel: detail.MaxLevel,
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// rollEncounter seçilen sürüm ve yöntemdeki slotlardan şansa göre ağırlıklı bir pokemon seçer.
+// method boşsa önce "walk" denenir, yoksa alfabetik ilk yöntem kullanılır.
+func rollEncounter(area pokeapi.LocationArea, version, method string, r *rand.Rand) (wildEncounter, error) {
+	slots := encounterSlots(area, version)
+	if len(slots) == 0 {
+		return wildEncounter{}, fmt.Errorf("No wild pokemon appear in %s in pokemon %s.", area.Name, version)
+	}
+	if method == "" {
+		if _, ok := slots["walk"]; ok {
+			method = "walk"
+		} else {
+			methods := make([]string, 0, len(slots))
+			for m := range slots {
+				methods = append(methods, m)
+			}
+			sort.Strings(methods)
+			method = methods[0]
+		}
+	}
+	candidates, ok := slots[method]
+	if !ok {
+		return wildEncounter{}, fmt.Errorf("No pokemon can be found by %s in %s.", method, area.Name)
+	}
+
+	total := 0
+	for _, slot := range candidates {
+		total += slot.chance
+	}
+	if total <= 0 {
+		return wildEncounter{}, fmt.Errorf("No pokemon can be found by %s in %s.", method, area.Name)
+	}
+	roll := r.Intn(total)
+	chosen := candidates[len(candidates)-1]
+	for _, slot := range candidates {
+		if roll < slot.chance {
+			chosen = slot
+			break
+		}
+		roll -= slot.chance
+	}
+
+	level := chosen.minLevel
+	if chosen.maxLevel > chosen.minLevel {
+		level += r.Intn(chosen.maxLevel - chosen.minLevel + 1)
+	}
+	var ivs battle.Stats
+	for i := range ivs {
+		ivs[i] = r.Intn(battle.MaxIV + 1)
+	}
+	return wildEncounter{
+		Pokemon:    chosen.pokemon,
+		Level:      level,
+		Area:       area.Name,
+		Method:     method,
+		Version:    version,
+		HPFraction: 1,
+		Status:     capture.StatusNone,
+		IVs:        ivs,
+	}, nil
+}
+
+func commandEncounter(ctx context.Context, cfg *config, args ...string) error {
+	if wild != nil {
+		return fmt.Errorf("A wild %s is still here! Catch it or flee first.", wild.Pokemon)
+	}
+	if saveData.Location == "" {
+		return fmt.Errorf("You are not in any area yet, goto one first.")
+	}
+	method := ""
+	if len(args) > 0 {
+		method = args[0]
+	}
+
+	area, err := client.GetLocationArea(ctx, saveData.Location)
+	if err != nil {
+		return err
+	}
+	v, err := currentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	version, err := pickEncounterVersion(area, v)
+	if err != nil {
+		return err
+	}
+
+	encounter, err := rollEncounter(area, version, method, rng)
+	if err != nil {
+		return err
+	}
+	wild = &encounter
+	fmt.Printf("A wild %s (Lv. %d) appeared! [%s, pokemon %s]\n", wild.Pokemon, wild.Level, wild.Method, wild.Version)
+	fmt.Printf("Try to catch it with: catch %s [ball], or flee.\n", wild.Pokemon)
+	return nil
+}
+
+func commandFlee(ctx context.Context, cfg *config, args ...string) error {
+	if wild == nil {
+		return fmt.Errorf("There is nothing to flee from.")
+	}
+	fmt.Printf("Got away safely from %s!\n", wild.Pokemon)
+	wild = nil
+	return nil
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/savefile"
+)
+
+func fetchEvolutionChain(ctx context.Context, speciesName string) (pokeapi.EvolutionChain, error) {
+	species, err := client.GetPokemonSpecies(ctx, speciesName)
+	if err != nil {
+		return pokeapi.EvolutionChain{}, err
+	}
+	if species.EvolutionChain.URL == "" {
+		return pokeapi.EvolutionChain{}, fmt.Errorf("%s has no evolution chain.", speciesName)
+	}
+	return client.GetEvolutionChain(ctx, species.EvolutionChain.URL)
+}
+
+func commandEvolution(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: evolution <pokemon>")
+	}
+	res, err := client.GetPokemon(ctx, strings.ToLower(args[0]))
+	if err != nil {
+		return err
+	}
+	chain, err := fetchEvolutionChain(ctx, res.Species.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderEvolutionTree(chain.Chain))
+	return nil
+}
+
+// renderEvolutionTree zinciri dallanan bir ağaç olarak yazar:
+//
+//	eevee
+//	├─ vaporeon (use water-stone)
+//	└─ umbreon (level up with high friendship at night)
+func renderEvolutionTree(root pokeapi.ChainLink) string {
+	var b strings.Builder
+	b.WriteString(root.Species.Name)
+	if root.IsBaby {
+		b.WriteString(" (baby)")
+	}
+	b.WriteString("\n")
+	renderEvolutionChildren(&b, root, "")
+	return b.String()
+}
+
+func renderEvolutionChildren(b *strings.Builder, link pokeapi.ChainLink, prefix string) {
+	for i, child := range link.EvolvesTo {
+		branch, next := "├─ ", "│  "
+		if i == len(link.EvolvesTo)-1 {
+			branch, next = "└─ ", "   "
+		}
+		fmt.Fprintf(b, "%s%s%s (%s)\n", prefix, branch, child.Species.Name, describeEvolution(child.EvolutionDetails))
+		renderEvolutionChildren(b, child, prefix+next)
+	}
+}
+
+// describeEvolution farklı oyunlardaki koşulları "ya da" ile birleştirir
+func describeEvolution(details []pokeapi.EvolutionDetail) string {
+	var parts []string
+	for _, d := range details {
+		text := describeEvolutionDetail(d)
+		if !slices.Contains(parts, text) {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, " or ")
+}
+
+func describeEvolutionDetail(d pokeapi.EvolutionDetail) string {
+	var text string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel > 0 {
+			text = fmt.Sprintf("level %d", d.MinLevel)
+		} else {
+			text = "level up"
+		}
+	case "use-item":
+		text = "use " + d.Item.Name
+	case "trade":
+		text = "trade"
+	default:
+		text = strings.ReplaceAll(d.Trigger.Name, "-", " ")
+	}
+
+	var conditions []string
+	if d.MinHappiness > 0 {
+		conditions = append(conditions, "with high friendship")
+	}
+	if d.MinAffection > 0 {
+		conditions = append(conditions, "with high affection")
+	}
+	if d.MinBeauty > 0 {
+		conditions = append(conditions, "with high beauty")
+	}
+	if d.HeldItem.Name != "" {
+		conditions = append(conditions, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove.Name != "" {
+		conditions = append(conditions, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType.Name != "" {
+		conditions = append(conditions, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location.Name != "" {
+		conditions = append(conditions, "at "+d.Location.Name)
+	}
+	if d.TradeSpecies.Name != "" {
+		conditions = append(conditions, "for "+d.TradeSpecies.Name)
+	}
+	if d.PartySpecies.Name != "" {
+		conditions = append(conditions, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType.Name != "" {
+		conditions = append(conditions, "with a "+d.PartyType.Name+" pokemon in the party")
+	}
+	if d.Gender != nil {
+		if *d.Gender == 1 {
+			conditions = append(conditions, "if female")
+		} else {
+			conditions = append(conditions, "if male")
+		}
+	}
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			conditions = append(conditions, "if attack > defense")
+		case -1:
+			conditions = append(conditions, "if attack < defense")
+		default:
+			conditions = append(conditions, "if attack = defense")
+		}
+	}
+	if d.NeedsOverworldRain {
+		conditions = append(conditions, "in the rain")
+	}
+	if d.TurnUpsideDown {
+		conditions = append(conditions, "holding the console upside down")
+	}
+	switch d.TimeOfDay {
+	case "day":
+		conditions = append(conditions, "during the day")
+	case "night":
+		conditions = append(conditions, "at night")
+	case "":
+	default:
+		conditions = append(conditions, "at "+d.TimeOfDay)
+	}
+	if len(conditions) == 0 {
+		return text
+	}
+	return text + " " + strings.Join(conditions, " ")
+}
+
+func timeOfDay(now time.Time) string {
+	switch hour := now.Hour(); {
+	case hour == 17:
+		return "dusk"
+	case hour >= 6 && hour < 17:
+		return "day"
+	}
+	return "night"
+}
+
+// evolutionOption pokemonun evrilebileceği bir tür. Reason boşsa kayıttaki
+// durum (seviye, çantadaki eşyalar, saat) koşulları karşılıyor demektir.
+type evolutionOption struct {
+	Species string
+	Item    string
+	Reason  string
+}
+
+func findChainLink(link pokeapi.ChainLink, species string) (pokeapi.ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, child := range link.EvolvesTo {
+		if found, ok := findChainLink(child, species); ok {
+			return found, true
+		}
+	}
+	return pokeapi.ChainLink{}, false
+}
+
+func evolutionOptions(link pokeapi.ChainLink, caught savefile.Caught, bag map[string]int, now time.Time) []evolutionOption {
+	var options []evolutionOption
+	for _, child := range link.EvolvesTo {
+		option := evolutionOption{Species: child.Species.Name}
+		var reasons []string
+		for _, d := range child.EvolutionDetails {
+			item, reason := checkEvolution(d, caught, bag, now)
+			if reason == "" {
+				option.Item = item
+				reasons = nil
+				break
+			}
+			if !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+		if len(child.EvolutionDetails) == 0 {
+			reasons = append(reasons, "has no known evolution method")
+		}
+		option.Reason = strings.Join(reasons, " or ")
+		options = append(options, option)
+	}
+	return options
+}
+
+// checkEvolution koşul sağlanıyorsa harcanacak eşyayı, sağlanmıyorsa nedenini döner.
+// Kayıtta tutmadığımız şeyler (arkadaşlık, bilinen hareketler, takas) karşılanamaz.
+func checkEvolution(d pokeapi.EvolutionDetail, caught savefile.Caught, bag map[string]int, now time.Time) (string, string) {
+	item := ""
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel > caught.Level {
+			return "", fmt.Sprintf("needs level %d (it is level %d)", d.MinLevel, caught.Level)
+		}
+	case "use-item":
+		if bag[d.Item.Name] == 0 {
+			return "", fmt.Sprintf("needs a %s in the bag", d.Item.Name)
+		}
+		item = d.Item.Name
+	default:
+		return "", "needs to " + describeEvolutionDetail(d)
+	}
+	if d.TimeOfDay != "" && d.TimeOfDay != timeOfDay(now) {
+		return "", "can only evolve at " + d.TimeOfDay
+	}
+	untracked := d.MinHappiness > 0 || d.MinAffection > 0 || d.MinBeauty > 0 ||
+		d.HeldItem.Name != "" || d.KnownMove.Name != "" || d.KnownMoveType.Name != "" ||
+		d.Location.Name != "" || d.PartySpecies.Name != "" || d.PartyType.Name != "" ||
+		d.Gender != nil || d.RelativePhysicalStats != nil || d.NeedsOverworldRain || d.TurnUpsideDown
+	if untracked {
+		return "", "needs to " + describeEvolutionDetail(d)
+	}
+	return item, ""
+}
+
+func commandEvolve(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: evolve <pokemon> [evolution]")
+	}
+	pokemonName := strings.ToLower(args[0])
+	caught, ok := saveData.Caught[pokemonName]
+	if !ok {
+		return fmt.Errorf("you have not caught that pokemon")
+	}
+
+	res, err := client.GetPokemon(ctx, pokemonName)
+	if err != nil {
+		return err
+	}
+	chain, err := fetchEvolutionChain(ctx, res.Species.Name)
+	if err != nil {
+		return err
+	}
+	link, ok := findChainLink(chain.Chain, res.Species.Name)
+	if !ok || len(link.EvolvesTo) == 0 {
+		return fmt.Errorf("%s does not evolve.", pokemonName)
+	}
+
+	options := evolutionOptions(link, caught, saveData.Bag, time.Now())
+	if len(args) > 1 {
+		wanted := strings.ToLower(args[1])
+		options = slices.DeleteFunc(options, func(o evolutionOption) bool { return o.Species != wanted })
+		if len(options) == 0 {
+			return fmt.Errorf("%s does not evolve into %s.", pokemonName, wanted)
+		}
+	}
+	var ready []evolutionOption
+	for _, option := range options {
+		if option.Reason != "" {
+			fmt.Printf("%s can't evolve into %s yet: it %s.\n", pokemonName, option.Species, option.Reason)
+			continue
+		}
+		ready = append(ready, option)
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	if len(ready) > 1 {
+		var names []string
+		for _, option := range ready {
+			names = append(names, option.Species)
+		}
+		return fmt.Errorf("%s can evolve into %s, choose one: evolve %s <evolution>", pokemonName, strings.Join(names, " or "), pokemonName)
+	}
+	return evolve(ctx, pokemonName, caught, ready[0])
+}
+
+func evolve(ctx context.Context, pokemonName string, caught savefile.Caught, option evolutionOption) error {
+	species, err := client.GetPokemonSpecies(ctx, option.Species)
+	if err != nil {
+		return err
+	}
+	// Tür adı her zaman pokemon adı değil (wormadam -> wormadam-plant)
+	target := option.Species
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			target = variety.Pokemon.Name
+		}
+	}
+	if _, ok := saveData.Caught[target]; ok {
+		return fmt.Errorf("You already have a %s.", target)
+	}
+	evolved, err := client.GetPokemon(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	if option.Item != "" {
+		saveData.Bag[option.Item]--
+		if saveData.Bag[option.Item] <= 0 {
+			delete(saveData.Bag, option.Item)
+		}
+		fmt.Printf("You used a %s on %s.\n", option.Item, pokemonName)
+	}
+	delete(saveData.Caught, pokemonName)
+	caught.Pokemon = savefile.FromAPI(evolved)
+	saveData.Caught[target] = caught
+	fmt.Printf("What? %s is evolving... Congratulations! Your %s evolved into %s!\n", pokemonName, pokemonName, target)
+	return persist()
+}
@@ -2,7 +2,6 @@ module github.com/ardamertdedeoglu/pokedexcli
 
 go 1.25.6
 
-require (
-	github.com/chzyer/readline v1.5.1 // indirect
-	golang.org/x/sys v0.40.0 // indirect
-)
+require github.com/chzyer/readline v1.5.1
+
+require golang.org/x/sys v0.40.0 // indirect
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+)
+
+// inspect ve lookup'ın özet dışındaki bölümleri, hepsi tam pokemon verisi ister
+type inspectSections struct {
+	Moves     bool
+	Abilities bool
+	Items     bool
+	Forms     bool
+	Cries     bool
+	Games     bool
+}
+
+func (s inspectSections) any() bool {
+	return s.Moves || s.Abilities || s.Items || s.Forms || s.Cries || s.Games
+}
+
+const inspectFlags = "[--moves] [--abilities] [--items] [--forms] [--cries] [--games] [--all]"
+
+func parseInspectArgs(args []string) (string, inspectSections, error) {
+	var name string
+	var s inspectSections
+	for _, arg := range args {
+		switch arg {
+		case "--moves":
+			s.Moves = true
+		case "--abilities":
+			s.Abilities = true
+		case "--items":
+			s.Items = true
+		case "--forms":
+			s.Forms = true
+		case "--cries":
+			s.Cries = true
+		case "--games":
+			s.Games = true
+		case "--all":
+			s = inspectSections{true, true, true, true, true, true}
+		default:
+			if strings.HasPrefix(arg, "-") || name != "" {
+				return "", s, fmt.Errorf("unknown option %q, use: %s", arg, inspectFlags)
+			}
+			name = strings.ToLower(arg)
+		}
+	}
+	return name, s, nil
+}
+
+func commandInspect(ctx context.Context, cfg *config, args ...string) error {
+	pokemonName, sections, err := parseInspectArgs(args)
+	if err != nil {
+		return err
+	}
+	if pokemonName == "" {
+		return fmt.Errorf("usage: inspect <pokemon> %s", inspectFlags)
+	}
+	caught, ok := saveData.Caught[pokemonName]
+	if !ok {
+		return fmt.Errorf("you have not caught that pokemon")
+	}
+	if !sections.any() {
+		printPokemonInfo(savedPokemonInfo(caught.Pokemon))
+		fmt.Printf("Level: %d\n", caught.Level)
+		if caught.Location != "" {
+			fmt.Printf("Caught at: %s\n", caught.Location)
+		}
+		return inspectVersion(ctx, pokemonName)
+	}
+
+	// Kayıtta sadece özet var, bölümler için tam veriyi client'tan (çoğunlukla cache'ten) alıyoruz
+	res, err := client.GetPokemon(ctx, pokemonName)
+	if err != nil {
+		return err
+	}
+	return printInspectSections(ctx, res, sections)
+}
+
+// inspectVersion sürüm seçiliyse pokemonun o oyunda olup olmadığını ve sprite'ını gösterir
+func inspectVersion(ctx context.Context, pokemonName string) error {
+	v, err := currentVersion(ctx)
+	if err != nil || v == nil {
+		return err
+	}
+	res, err := client.GetPokemon(ctx, pokemonName)
+	if err != nil {
+		return err
+	}
+	if !pokemonInVersion(res, v) {
+		fmt.Printf("%s doesn't exist in pokemon %s.\n", pokemonName, v.Name)
+		return nil
+	}
+	if v.Group != "" {
+		fmt.Printf("Sprite (%s): %s\n", v.Group, res.SpriteFor(v.Group))
+	}
+	return nil
+}
+
+func printInspectSections(ctx context.Context, res pokeapi.Pokemon, s inspectSections) error {
+	v, err := currentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if v != nil && !pokemonInVersion(res, v) {
+		return fmt.Errorf("%s doesn't exist in pokemon %s.", res.Name, v.Name)
+	}
+
+	if s.Abilities {
+		fmt.Println("Abilities:")
+		for _, a := range res.Abilities {
+			if a.IsHidden {
+				fmt.Printf("  %d. %s (hidden)\n", a.Slot, a.Ability.Name)
+			} else {
+				fmt.Printf("  %d. %s\n", a.Slot, a.Ability.Name)
+			}
+		}
+	}
+	if s.Moves {
+		group := ""
+		if v != nil {
+			group = v.Group
+		}
+		if group == "" {
+			group = defaultMoveGroup(res)
+		}
+		printMoves(res, group, v == nil)
+	}
+	if s.Items {
+		printHeldItems(res, v)
+	}
+	if s.Forms {
+		fmt.Println("Forms:")
+		for _, f := range res.Forms {
+			fmt.Printf("  - %s\n", f.Name)
+		}
+	}
+	if s.Cries {
+		fmt.Println("Cries:")
+		if res.Cries.Latest != "" {
+			fmt.Printf("  latest: %s\n", res.Cries.Latest)
+		}
+		if res.Cries.Legacy != "" {
+			fmt.Printf("  legacy: %s\n", res.Cries.Legacy)
+		}
+	}
+	if s.Games {
+		fmt.Println("Games:")
+		if len(res.GameIndices) == 0 {
+			fmt.Println("  (no game index data)")
+		}
+		for _, g := range res.GameIndices {
+			fmt.Printf("  - %s (#%d)\n", g.Version.Name, g.GameIndex)
+		}
+	}
+	return nil
+}
+
+// defaultMoveGroup sürüm seçilmemişse en çok hareket verisi olan sürüm grubunu seçer
+func defaultMoveGroup(res pokeapi.Pokemon) string {
+	counts := make(map[string]int)
+	for _, move := range res.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			counts[detail.VersionGroup.Name]++
+		}
+	}
+	best := ""
+	for group, n := range counts {
+		if n > counts[best] || (n == counts[best] && group < best) {
+			best = group
+		}
+	}
+	return best
+}
+
+func printMoves(res pokeapi.Pokemon, group string, guessed bool) {
+	moves := learnset(res, group)
+	if len(moves) == 0 {
+		fmt.Println("Moves: none")
+		return
+	}
+	fmt.Printf("Moves (%s):\n", group)
+	if guessed {
+		fmt.Println("  (pick another game with: version <name>)")
+	}
+	method := ""
+	for _, move := range moves {
+		if move.Method != method {
+			method = move.Method
+			fmt.Printf("  %s:\n", method)
+		}
+		if method == "level-up" {
+			fmt.Printf("    Lv. %2d %s\n", move.Level, move.Name)
+		} else {
+			fmt.Printf("    %s\n", move.Name)
+		}
+	}
+}
+
+func printHeldItems(res pokeapi.Pokemon, v *gameVersion) {
+	fmt.Println("Held items:")
+	found := 0
+	for _, item := range res.HeldItems {
+		var details []string
+		for _, vd := range item.VersionDetails {
+			if v != nil && !v.has(vd.Version.Name) {
+				continue
+			}
+			details = append(details, fmt.Sprintf("%d%% in %s", vd.Rarity, vd.Version.Name))
+		}
+		if len(details) == 0 {
+			continue
+		}
+		sort.Strings(details)
+		fmt.Printf("  - %s (%s)\n", item.Item.Name, strings.Join(details, ", "))
+		found++
+	}
+	if found == 0 {
+		fmt.Println("  (none)")
+	}
+}
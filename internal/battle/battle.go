@@ -0,0 +1,173 @@
+package battle
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Ana seri (6. nesil ve sonrası) hasar formülü, kritik vuruş ve sıra kuralları.
+// https://bulbapedia.bulbagarden.net/wiki/Damage
+
+type Move struct {
+	Name string
+	// Boşsa türsüz (struggle), tür çarpanı ve STAB uygulanmaz
+	Type string
+	// physical, special ya da status
+	Class string
+	Power int
+	// 0 asla ıskalamaz
+	Accuracy int
+	Priority int
+}
+
+// Struggle hiç hasar veren hareketi olmayan pokemonlar için
+var Struggle = Move{Name: "struggle", Class: "physical", Power: 50}
+
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats Stats
+	HP    int
+	Moves []Move
+}
+
+func NewCombatant(name string, level int, types []string, base, ivs, evs Stats, moves []Move) *Combatant {
+	c := &Combatant{
+		Name:  name,
+		Level: level,
+		Types: types,
+		Stats: Calculate(base, ivs, evs, level),
+		Moves: moves,
+	}
+	c.HP = c.Stats[HP]
+	if len(c.Moves) == 0 {
+		c.Moves = []Move{Struggle}
+	}
+	return c
+}
+
+func (c *Combatant) MaxHP() int {
+	return c.Stats[HP]
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+// TypeChart typechart.Chart'ın karşıladığı arayüz
+type TypeChart interface {
+	Effectiveness(attacking string, defending ...string) float64
+}
+
+type Battle struct {
+	Player   *Combatant
+	Opponent *Combatant
+	Round    int
+	chart    TypeChart
+	r        *rand.Rand
+}
+
+// New r'yi tüm şans işlerinde kullanır, aynı tohumla aynı savaş tekrar oynanır
+func New(player, opponent *Combatant, chart TypeChart, r *rand.Rand) *Battle {
+	return &Battle{Player: player, Opponent: opponent, chart: chart, r: r}
+}
+
+type Event struct {
+	Attacker string
+	Defender string
+	Move     string
+	Missed   bool
+	Critical bool
+	// Status hareketleri bu simülasyonda bir şey yapmıyor
+	NoEffect      bool
+	Effectiveness float64
+	Damage        int
+	Fainted       bool
+}
+
+func (b *Battle) Over() bool {
+	return b.Player.Fainted() || b.Opponent.Fainted()
+}
+
+func (b *Battle) OpponentMove() int {
+	return b.r.Intn(len(b.Opponent.Moves))
+}
+
+// PlayTurn iki tarafın seçtiği hareketleri öncelik ve hıza göre sırayla uygular.
+// İlk saldırıda bayılan taraf karşılık veremez.
+func (b *Battle) PlayTurn(playerMove, opponentMove int) []Event {
+	b.Round++
+	pm, om := b.Player.Moves[playerMove], b.Opponent.Moves[opponentMove]
+	first, second := b.Player, b.Opponent
+	firstMove, secondMove := pm, om
+	if !b.playerFirst(pm, om) {
+		first, second = second, first
+		firstMove, secondMove = secondMove, firstMove
+	}
+
+	events := []Event{b.attack(first, second, firstMove)}
+	if !second.Fainted() {
+		events = append(events, b.attack(second, first, secondMove))
+	}
+	return events
+}
+
+func (b *Battle) playerFirst(pm, om Move) bool {
+	if pm.Priority != om.Priority {
+		return pm.Priority > om.Priority
+	}
+	if b.Player.Stats[Speed] != b.Opponent.Stats[Speed] {
+		return b.Player.Stats[Speed] > b.Opponent.Stats[Speed]
+	}
+	// Hızlar eşitse yazı tura
+	return b.r.Intn(2) == 0
+}
+
+func (b *Battle) attack(attacker, defender *Combatant, move Move) Event {
+	ev := Event{Attacker: attacker.Name, Defender: defender.Name, Move: move.Name, Effectiveness: 1}
+	if move.Class == "status" || move.Power == 0 {
+		ev.NoEffect = true
+		return ev
+	}
+	if move.Accuracy > 0 && b.r.Intn(100) >= move.Accuracy {
+		ev.Missed = true
+		return ev
+	}
+	if move.Type != "" {
+		ev.Effectiveness = b.chart.Effectiveness(move.Type, defender.Types...)
+	}
+	if ev.Effectiveness == 0 {
+		return ev
+	}
+	// Kritik şansı 1/24, hasar 0.85 ile 1 arasında rastgele
+	ev.Critical = b.r.Intn(24) == 0
+	random := 85 + b.r.Intn(16)
+	ev.Damage = Damage(attacker, defender, move, ev.Effectiveness, ev.Critical, random)
+	defender.HP = max(0, defender.HP-ev.Damage)
+	ev.Fainted = defender.Fainted()
+	return ev
+}
+
+// Damage ((2*Lv/5+2) * Güç * A/D / 50 + 2) * kritik * rastgele/100 * STAB * tür,
+// oyunlardaki gibi her adımda aşağı yuvarlanır
+func Damage(attacker, defender *Combatant, move Move, effectiveness float64, critical bool, random int) int {
+	atk, def := Attack, Defense
+	if move.Class == "special" {
+		atk, def = SpAttack, SpDefense
+	}
+	damage := float64((2*attacker.Level/5+2)*move.Power*attacker.Stats[atk]/max(defender.Stats[def], 1)/50 + 2)
+	if critical {
+		damage = math.Floor(damage * 1.5)
+	}
+	damage = math.Floor(damage * float64(random) / 100)
+	if move.Type != "" && slices.Contains(attacker.Types, move.Type) {
+		damage = math.Floor(damage * 1.5)
+	}
+	damage = math.Floor(damage * effectiveness)
+	if damage < 1 && effectiveness > 0 {
+		return 1
+	}
+	return int(damage)
+}
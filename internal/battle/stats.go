@@ -0,0 +1,69 @@
+package battle
+
+// Ana seri (3. nesil ve sonrası) stat formülü, nature etkisi yok.
+// https://bulbapedia.bulbagarden.net/wiki/Stat
+
+type Stat int
+
+const (
+	HP Stat = iota
+	Attack
+	Defense
+	SpAttack
+	SpDefense
+	Speed
+)
+
+// PokeAPI'deki stat adları
+var statNames = [...]string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+func (s Stat) String() string {
+	return statNames[s]
+}
+
+func ParseStat(name string) (Stat, bool) {
+	for i, n := range statNames {
+		if n == name {
+			return Stat(i), true
+		}
+	}
+	return 0, false
+}
+
+type Stats [6]int
+
+const (
+	MaxIV      = 31
+	MaxStatEV  = 252
+	MaxTotalEV = 510
+)
+
+func Calculate(base, ivs, evs Stats, level int) Stats {
+	var s Stats
+	for i := range s {
+		core := (2*base[i] + ivs[i] + evs[i]/4) * level / 100
+		if Stat(i) == HP {
+			s[i] = core + level + 10
+		} else {
+			s[i] = core + 5
+		}
+	}
+	return s
+}
+
+// GainEVs yenilen pokemonun EV kazancını stat başına ve toplam sınırlara göre ekler
+func GainEVs(evs, yield Stats) Stats {
+	total := 0
+	for _, ev := range evs {
+		total += ev
+	}
+	for i := range evs {
+		gain := min(yield[i], MaxStatEV-evs[i], MaxTotalEV-total)
+		if gain <= 0 {
+			continue
+		}
+		evs[i] += gain
+		total += gain
+	}
+	return evs
+}
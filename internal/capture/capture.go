@@ -0,0 +1,108 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Ana seri oyunlardaki (3. ve 4. nesil) yakalama formülü.
+// https://bulbapedia.bulbagarden.net/wiki/Catch_rate
+
+type Status int
+
+const (
+	StatusNone Status = iota
+	StatusSleep
+	StatusFreeze
+	StatusParalysis
+	StatusPoison
+	StatusBurn
+)
+
+func (s Status) Modifier() float64 {
+	switch s {
+	case StatusSleep, StatusFreeze:
+		return 2
+	case StatusParalysis, StatusPoison, StatusBurn:
+		return 1.5
+	}
+	return 1
+}
+
+type Attempt struct {
+	// Türün pokemon-species'teki capture_rate değeri, 1-255
+	CaptureRate int
+	// Kalan HP / maksimum HP, 0 ile 1 arası
+	HPFraction float64
+	// Poke Ball 1, Great Ball 1.5, Ultra Ball 2, Master Ball 255
+	Ball   float64
+	Status Status
+}
+
+// Topların yakalama çarpanları, özel koşullu toplar (Net Ball, Dusk Ball vb.)
+// koşulları hesaplanmadığı için 1 sayılır
+var ballModifiers = map[string]float64{
+	"poke-ball":    1,
+	"great-ball":   1.5,
+	"ultra-ball":   2,
+	"master-ball":  255,
+	"safari-ball":  1.5,
+	"sport-ball":   1.5,
+	"premier-ball": 1,
+	"luxury-ball":  1,
+	"heal-ball":    1,
+	"cherish-ball": 1,
+}
+
+// BallModifier bilinmeyen toplar için 1 ve false döner
+func BallModifier(ball string) (float64, bool) {
+	m, ok := ballModifiers[ball]
+	if !ok {
+		return 1, false
+	}
+	return m, true
+}
+
+// modifiedRate formüldeki "a" değeri
+func (a Attempt) modifiedRate() float64 {
+	hp := a.HPFraction
+	if hp <= 0 || hp > 1 {
+		hp = 1
+	}
+	ball := a.Ball
+	if ball <= 0 {
+		ball = 1
+	}
+	return (3 - 2*hp) / 3 * float64(a.CaptureRate) * ball * a.Status.Modifier()
+}
+
+// shakeThreshold her sallanma kontrolünde rastgele sayının altında kalması gereken "b" değeri
+func shakeThreshold(rate float64) float64 {
+	return 1048560 / math.Sqrt(math.Sqrt(16711680/rate))
+}
+
+func Probability(a Attempt) float64 {
+	rate := a.modifiedRate()
+	if rate >= 255 {
+		return 1
+	}
+	if rate <= 0 {
+		return 0
+	}
+	return math.Pow(shakeThreshold(rate)/65536, 4)
+}
+
+// Throw topu atar, yakalandıysa true ve kaç kere sallandığını döner
+func Throw(a Attempt, rng *rand.Rand) (bool, int) {
+	rate := a.modifiedRate()
+	if rate >= 255 {
+		return true, 4
+	}
+	b := shakeThreshold(rate)
+	for shakes := 0; shakes < 4; shakes++ {
+		if float64(rng.Intn(65536)) >= b {
+			return false, shakes
+		}
+	}
+	return true, 4
+}
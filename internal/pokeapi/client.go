@@ -0,0 +1,404 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokecache"
+)
+
+const DefaultBaseURL = "https://pokeapi.co/api/v2/"
+
+const apiPrefix = "/api/v2/"
+
+type Client struct {
+	cache      *pokecache.Cache
+	httpClient http.Client
+	baseURL    string
+	offline    bool
+	bundleDir  string
+	retry      retryPolicy
+	limiter    *limiter
+	onThrottle func(time.Duration)
+	// Her denemenin kendi süresi, 0 sınırsız
+	attemptTimeout time.Duration
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL == "" {
+			return
+		}
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// Offline modda ağa hiç çıkılmaz, sadece cache ve bundle kullanılır
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// bundleDir PokeAPI'nin api-data reposundaki gibi <kaynak>/<isim>/index.json
+// düzeninde JSON dosyaları tutar
+func WithBundle(bundleDir string) Option {
+	return func(c *Client) {
+		c.bundleDir = bundleDir
+	}
+}
+
+// maxAttempts ilk istek dahil toplam deneme sayısı, 1 tekrar denemeyi kapatır
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		c.retry = retryPolicy{
+			maxAttempts: maxAttempts,
+			baseDelay:   baseDelay,
+			maxDelay:    maxDelay,
+		}
+	}
+}
+
+// WithAttemptTimeout her HTTP denemesine ayrı süre verir, böylece takılan bir
+// deneme zaman aşımına uğrar ve tekrar denenebilir. 0 sınırsız.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.attemptTimeout = timeout
+	}
+}
+
+// rps 0 ya da daha küçükse sınırlama yapılmaz. Cache'ten gelen cevaplar token harcamaz.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newLimiter(rps, burst)
+	}
+}
+
+// notice istek sınırlama yüzünden bekletilirken ne kadar bekleneceğiyle çağrılır
+func WithThrottleNotice(notice func(wait time.Duration)) Option {
+	return func(c *Client) {
+		c.onThrottle = notice
+	}
+}
+
+func NewClient(cache *pokecache.Cache, opts ...Option) *Client {
+	c := Client{
+		cache:   cache,
+		baseURL: DefaultBaseURL,
+		retry:   defaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c
+}
+
+func (c *Client) LocationAreasURL() string {
+	return c.baseURL + "location-area/"
+}
+
+// resolve sayfalama linklerini de bizim base URL'imize çevirir. Mirror'lar
+// genelde next/previous alanlarında hala pokeapi.co adresini döner.
+func (c *Client) resolve(rawURL string) string {
+	if rawURL == "" || strings.HasPrefix(rawURL, c.baseURL) {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if !u.IsAbs() {
+		base, err := url.Parse(c.baseURL)
+		if err != nil {
+			return rawURL
+		}
+		return base.ResolveReference(u).String()
+	}
+	if i := strings.Index(u.Path, apiPrefix); i >= 0 {
+		rel := strings.TrimPrefix(u.Path[i+len(apiPrefix):], "/")
+		if u.RawQuery != "" {
+			rel += "?" + u.RawQuery
+		}
+		return c.baseURL + rel
+	}
+	return rawURL
+}
+
+func (c *Client) ListLocationAreas(ctx context.Context, pageURL string) (LocationAreaList, error) {
+	if pageURL == "" {
+		pageURL = c.LocationAreasURL()
+	}
+	pageURL = c.resolve(pageURL)
+	var result LocationAreaList
+	err := c.get(ctx, pageURL, "location-area page", pageURL, &result)
+	result.Next = c.resolve(result.Next)
+	result.Previous = c.resolve(result.Previous)
+	return result, err
+}
+
+func (c *Client) RegionsURL() string {
+	return c.baseURL + "region/"
+}
+
+func (c *Client) ListRegions(ctx context.Context, pageURL string) (RegionList, error) {
+	if pageURL == "" {
+		pageURL = c.RegionsURL()
+	}
+	pageURL = c.resolve(pageURL)
+	var result RegionList
+	err := c.get(ctx, pageURL, "region page", pageURL, &result)
+	result.Next = c.resolve(result.Next)
+	result.Previous = c.resolve(result.Previous)
+	return result, err
+}
+
+func (c *Client) GetRegion(ctx context.Context, name string) (Region, error) {
+	var result Region
+	err := c.get(ctx, c.baseURL+"region/"+name+"/", "region", name, &result)
+	return result, err
+}
+
+func (c *Client) GetLocationArea(ctx context.Context, name string) (LocationArea, error) {
+	var result LocationArea
+	err := c.get(ctx, c.baseURL+"location-area/"+name+"/", "location area", name, &result)
+	return result, err
+}
+
+func (c *Client) GetLocation(ctx context.Context, name string) (Location, error) {
+	var result Location
+	err := c.get(ctx, c.baseURL+"location/"+name+"/", "location", name, &result)
+	return result, err
+}
+
+func (c *Client) GetVersion(ctx context.Context, name string) (Version, error) {
+	var result Version
+	err := c.get(ctx, c.baseURL+"version/"+name+"/", "version", name, &result)
+	return result, err
+}
+
+func (c *Client) GetVersionGroup(ctx context.Context, name string) (VersionGroup, error) {
+	var result VersionGroup
+	err := c.get(ctx, c.baseURL+"version-group/"+name+"/", "version group", name, &result)
+	return result, err
+}
+
+func (c *Client) GetPokemon(ctx context.Context, name string) (Pokemon, error) {
+	var result Pokemon
+	err := c.get(ctx, c.baseURL+"pokemon/"+name+"/", "pokemon", name, &result)
+	return result, err
+}
+
+func (c *Client) GetPokemonSpecies(ctx context.Context, name string) (PokemonSpecies, error) {
+	var result PokemonSpecies
+	err := c.get(ctx, c.baseURL+"pokemon-species/"+name+"/", "pokemon species", name, &result)
+	return result, err
+}
+
+// GetEvolutionChain species'in evolution_chain linkindeki zinciri getirir
+func (c *Client) GetEvolutionChain(ctx context.Context, chainURL string) (EvolutionChain, error) {
+	var result EvolutionChain
+	chainURL = c.resolve(chainURL)
+	err := c.get(ctx, chainURL, "evolution chain", chainURL, &result)
+	return result, err
+}
+
+func (c *Client) GetType(ctx context.Context, name string) (Type, error) {
+	var result Type
+	err := c.get(ctx, c.baseURL+"type/"+name+"/", "type", name, &result)
+	return result, err
+}
+
+func (c *Client) GetMove(ctx context.Context, name string) (Move, error) {
+	var result Move
+	err := c.get(ctx, c.baseURL+"move/"+name+"/", "move", name, &result)
+	return result, err
+}
+
+func (c *Client) GetItem(ctx context.Context, name string) (Item, error) {
+	var result Item
+	err := c.get(ctx, c.baseURL+"item/"+name+"/", "item", name, &result)
+	return result, err
+}
+
+// get önce cache'e bakar, yoksa indirir ve başarılı cevabı cache'e yazar.
+// Aynı URL'i aynı anda isteyenler tek bir isteği paylaşır.
+func (c *Client) get(ctx context.Context, reqURL, resource, name string, v any) error {
+	if data, ok := c.cache.Get(reqURL); ok {
+		return decode(data, resource, v)
+	}
+	if c.offline {
+		return c.getOffline(reqURL, resource, name, v)
+	}
+
+	// fetch ilk çağıranın değil, paylaşılan indirmenin context'iyle çalışır
+	data, _, err := c.cache.Do(ctx, reqURL, func(fetchCtx context.Context) ([]byte, error) {
+		return c.fetch(fetchCtx, reqURL, resource, name)
+	})
+	if err != nil {
+		return err
+	}
+	return decode(data, resource, v)
+}
+
+// fetch süresi dolmuş kayıt varsa koşullu istek atar, 304 gelirse eldeki veriyi döner
+func (c *Client) fetch(ctx context.Context, reqURL, resource, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	stale, validators, hasStale := c.cache.GetStale(reqURL)
+	if hasStale {
+		if validators.ETag != "" {
+			req.Header.Set("If-None-Match", validators.ETag)
+		}
+		if validators.LastModified != "" {
+			req.Header.Set("If-Modified-Since", validators.LastModified)
+		}
+	}
+
+	var res *http.Response
+	cancel := context.CancelFunc(func() {})
+	for attempt := 1; ; attempt++ {
+		if err = c.throttle(ctx); err != nil {
+			return nil, err
+		}
+		var attemptCtx context.Context
+		attemptCtx, cancel = c.attemptContext(ctx)
+		res, err = c.httpClient.Do(req.WithContext(attemptCtx))
+		delay, retry := c.retry.shouldRetry(attempt, res, err)
+		if !retry || ctx.Err() != nil {
+			break
+		}
+		if res != nil {
+			drain(res)
+		}
+		cancel()
+		if err = sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	// Gövde okunana kadar son denemenin context'i açık kalmalı
+	defer cancel()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("error getting %s: %w", resource, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && hasStale {
+		c.cache.Refresh(reqURL, pokecache.Validators{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+		})
+		return stale, nil
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return nil, &NotFoundError{Resource: resource, Name: name}
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &StatusError{URL: reqURL, StatusCode: res.StatusCode}
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", resource, err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("error decoding %s: invalid JSON", resource)
+	}
+	c.cache.AddWithValidators(reqURL, data, pokecache.Validators{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	})
+	return data, nil
+}
+
+func (c *Client) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.attemptTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.attemptTimeout)
+}
+
+func (c *Client) throttle(ctx context.Context) error {
+	wait := c.limiter.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	if c.onThrottle != nil {
+		c.onThrottle(wait)
+	}
+	return sleep(ctx, wait)
+}
+
+// sleep beklerken context iptal edilirse hemen döner
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) getOffline(reqURL, resource, name string, v any) error {
+	if data, _, ok := c.cache.GetStale(reqURL); ok {
+		return decode(data, resource, v)
+	}
+	if data, ok := c.readBundle(reqURL); ok {
+		if err := decode(data, resource, v); err != nil {
+			return err
+		}
+		c.cache.Add(reqURL, data)
+		return nil
+	}
+	return &OfflineError{Resource: resource, Name: name}
+}
+
+func (c *Client) readBundle(reqURL string) ([]byte, bool) {
+	if c.bundleDir == "" || !strings.HasPrefix(reqURL, c.baseURL) {
+		return nil, false
+	}
+	rel := strings.TrimPrefix(reqURL, c.baseURL)
+	if strings.Contains(rel, "?") {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(c.bundleDir, filepath.FromSlash(rel), "index.json"))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func decode(data []byte, resource string, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", resource, err)
+	}
+	return nil
+}
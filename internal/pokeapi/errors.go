@@ -0,0 +1,45 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found")
+
+var ErrOffline = errors.New("not available offline")
+
+type NotFoundError struct {
+	Resource string
+	Name     string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %q not found", e.Resource, e.Name)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s", e.StatusCode, e.URL)
+}
+
+type OfflineError struct {
+	Resource string
+	Name     string
+}
+
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("%s %q is not available offline (not in the cache or the data bundle)", e.Resource, e.Name)
+}
+
+func (e *OfflineError) Is(target error) bool {
+	return target == ErrOffline
+}
@@ -0,0 +1,94 @@
+package pokeapi
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxAttempts: 3,
+		baseDelay:   200 * time.Millisecond,
+		maxDelay:    5 * time.Second,
+	}
+}
+
+// shouldRetry kaçıncı denemede olduğumuza ve sonuca bakıp tekrar denenip
+// denenmeyeceğine ve ne kadar bekleneceğine karar verir
+func (p retryPolicy) shouldRetry(attempt int, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.maxAttempts {
+		return 0, false
+	}
+	if err != nil {
+		return p.backoff(attempt), isTransient(err)
+	}
+	if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+		return 0, false
+	}
+	if wait, ok := retryAfter(res); ok {
+		// Sunucu bizim bekleyebileceğimizden uzun süre istiyorsa vazgeçiyoruz
+		if wait > p.maxDelay {
+			return 0, false
+		}
+		return wait, true
+	}
+	return p.backoff(attempt), true
+}
+
+// Üstel bekleme, üstüne yarısı kadar rastgele jitter
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isTransient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func drain(res *http.Response) {
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
@@ -0,0 +1,36 @@
+package pokeapi
+
+type EvolutionChain struct {
+	ID              int           `json:"id"`
+	BabyTriggerItem NamedResource `json:"baby_trigger_item"`
+	Chain           ChainLink     `json:"chain"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail'deki boş (null) alanlar sıfır değerde kalır
+type EvolutionDetail struct {
+	Trigger               NamedResource `json:"trigger"`
+	Item                  NamedResource `json:"item"`
+	HeldItem              NamedResource `json:"held_item"`
+	KnownMove             NamedResource `json:"known_move"`
+	KnownMoveType         NamedResource `json:"known_move_type"`
+	Location              NamedResource `json:"location"`
+	PartySpecies          NamedResource `json:"party_species"`
+	PartyType             NamedResource `json:"party_type"`
+	TradeSpecies          NamedResource `json:"trade_species"`
+	Gender                *int          `json:"gender"`
+	MinLevel              int           `json:"min_level"`
+	MinHappiness          int           `json:"min_happiness"`
+	MinBeauty             int           `json:"min_beauty"`
+	MinAffection          int           `json:"min_affection"`
+	RelativePhysicalStats *int          `json:"relative_physical_stats"`
+	TimeOfDay             string        `json:"time_of_day"`
+	NeedsOverworldRain    bool          `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool          `json:"turn_upside_down"`
+}
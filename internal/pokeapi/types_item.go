@@ -0,0 +1,38 @@
+package pokeapi
+
+type Item struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Cost     int           `json:"cost"`
+	Category NamedResource `json:"category"`
+	Names    []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+// EnglishName bulunamazsa API adını döner
+func (i Item) EnglishName() string {
+	for _, n := range i.Names {
+		if n.Language.Name == "en" {
+			return n.Name
+		}
+	}
+	return i.Name
+}
+
+func (i Item) IsBall() bool {
+	switch i.Category.Name {
+	case "standard-balls", "special-balls", "apricorn-balls":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package pokeapi
+
+// Move'daki null alanlar (power, accuracy) 0 olarak kalır
+type Move struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Accuracy    int           `json:"accuracy"`
+	Power       int           `json:"power"`
+	PP          int           `json:"pp"`
+	Priority    int           `json:"priority"`
+	Type        NamedResource `json:"type"`
+	DamageClass NamedResource `json:"damage_class"`
+}
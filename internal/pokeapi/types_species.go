@@ -0,0 +1,89 @@
+package pokeapi
+
+import (
+	"slices"
+	"strings"
+)
+
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type PokemonSpecies struct {
+	ID                 int             `json:"id"`
+	Name               string          `json:"name"`
+	Order              int             `json:"order"`
+	GenderRate         int             `json:"gender_rate"`
+	CaptureRate        int             `json:"capture_rate"`
+	BaseHappiness      int             `json:"base_happiness"`
+	IsBaby             bool            `json:"is_baby"`
+	IsLegendary        bool            `json:"is_legendary"`
+	IsMythical         bool            `json:"is_mythical"`
+	HatchCounter       int             `json:"hatch_counter"`
+	GrowthRate         NamedResource   `json:"growth_rate"`
+	EggGroups          []NamedResource `json:"egg_groups"`
+	Color              NamedResource   `json:"color"`
+	Shape              NamedResource   `json:"shape"`
+	Habitat            NamedResource   `json:"habitat"`
+	Generation         NamedResource   `json:"generation"`
+	EvolvesFromSpecies NamedResource   `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	PokedexNumbers []struct {
+		EntryNumber int           `json:"entry_number"`
+		Pokedex     NamedResource `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Names []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+	Genera []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   NamedResource `json:"language"`
+		Version    NamedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Varieties []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   NamedResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+func (s PokemonSpecies) EnglishGenus() string {
+	for _, g := range s.Genera {
+		if g.Language.Name == "en" {
+			return g.Genus
+		}
+	}
+	return ""
+}
+
+// FlavorText verilen sürümlerden birine ait İngilizce metni döner, yoksa en son
+// eklenen İngilizce metni. Metinlerdeki satır sonları boşluğa çevrilir.
+func (s PokemonSpecies) FlavorText(versions ...string) string {
+	text := ""
+	for _, entry := range s.FlavorTextEntries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		if slices.Contains(versions, entry.Version.Name) {
+			return strings.Join(strings.Fields(entry.FlavorText), " ")
+		}
+		text = entry.FlavorText
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func (s PokemonSpecies) NationalDexNumber() int {
+	for _, n := range s.PokedexNumbers {
+		if n.Pokedex.Name == "national" {
+			return n.EntryNumber
+		}
+	}
+	return s.ID
+}
@@ -0,0 +1,15 @@
+package pokeapi
+
+type Type struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	Generation NamedResource `json:"generation"`
+}
@@ -0,0 +1,14 @@
+package pokeapi
+
+type Version struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	VersionGroup NamedResource `json:"version_group"`
+}
+
+type VersionGroup struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Generation NamedResource   `json:"generation"`
+	Versions   []NamedResource `json:"versions"`
+}
@@ -1,6 +1,7 @@
 package pokecache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
@@ -9,48 +10,266 @@ type Cache struct {
 	cacheEntries map[string]CacheEntry
 	mux          sync.Mutex
 	interval     time.Duration
+	dir          string
+	diskTTL      time.Duration
+	maxEntries   int
+	maxBytes     int
+	totalBytes   int
+	recency      *list.List
+	done         chan struct{}
+	closed       bool
+	inFlight     map[string]*call
+	hits         int
+	misses       int
+	shared       int
 }
 
 type CacheEntry struct {
-	createdAt time.Time
-	val       []byte
+	createdAt  time.Time
+	val        []byte
+	elem       *list.Element
+	validators Validators
+	stale      bool
 }
 
-func NewCache(interval time.Duration) *Cache {
+// Validators sunucunun cevapla birlikte verdiği ETag/Last-Modified değerleri.
+// Süresi dolan ama validator'ı olan kayıtlar silinmez, stale olarak kalır ve
+// koşullu istekle yeniden doğrulanabilir.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+func (v Validators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// merge boş olmayan yeni değerleri eskilerin üstüne yazar
+func (v Validators) merge(newer Validators) Validators {
+	if newer.ETag != "" {
+		v.ETag = newer.ETag
+	}
+	if newer.LastModified != "" {
+		v.LastModified = newer.LastModified
+	}
+	return v
+}
+
+type Option func(*Cache)
+
+// 0 sınırsız demek
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+func NewCache(interval time.Duration, opts ...Option) *Cache {
 	c := Cache{
 		cacheEntries: make(map[string]CacheEntry),
 		mux:          sync.Mutex{},
 		interval:     interval,
+		recency:      list.New(),
+		done:         make(chan struct{}),
+		inFlight:     make(map[string]*call),
+	}
+	for _, opt := range opts {
+		opt(&c)
 	}
 	go c.reapLoop()
 	return &c
 }
 
+// Close'dan sonra Add hiçbir şey yapmaz, Get her zaman bulunamadı döner
+func (c *Cache) Close() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.done)
+	c.cacheEntries = make(map[string]CacheEntry)
+	c.recency.Init()
+	c.totalBytes = 0
+}
+
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithValidators(key, val, Validators{})
+}
+
+func (c *Cache) AddWithValidators(key string, val []byte, validators Validators) {
+	now := time.Now()
 	c.mux.Lock()
-	c.cacheEntries[key] = CacheEntry{createdAt: time.Now(), val: val}
+	if c.closed {
+		c.mux.Unlock()
+		return
+	}
+	c.addLocked(key, val, validators, now)
 	c.mux.Unlock()
+	if c.dir != "" {
+		c.writeDisk(key, val, validators, now)
+	}
 }
 func (c *Cache) Get(key string) ([]byte, bool) {
 	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return nil, false
+	}
+	res, ok := c.cacheEntries[key]
+	if ok {
+		c.recency.MoveToFront(res.elem)
+	}
+	// Reap döngüsünü beklemeden, süresi geçmiş kaydı taze saymıyoruz
+	if ok && !res.stale && time.Since(res.createdAt) <= c.interval {
+		c.hits++
+		c.mux.Unlock()
+		return res.val, ok
+	}
+	c.mux.Unlock()
+
+	// Bellekte yoksa diske bak, bulursak tekrar belleğe al
+	var entry diskEntry
+	if c.dir != "" {
+		entry, ok = c.readDisk(key)
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.dir == "" || !ok || entry.expired() {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	if !c.closed {
+		c.addLocked(key, entry.Val, entry.validators(), time.Now())
+	}
+	return entry.Val, true
+
+}
+
+// GetStale Get gibidir ama süresi dolmuş kayıtları da validator'larıyla döner.
+// Get'ten hemen sonra çağrıldığı için isabet ve ıskaları saymaz.
+func (c *Cache) GetStale(key string) ([]byte, Validators, bool) {
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return nil, Validators{}, false
+	}
 	res, ok := c.cacheEntries[key]
 	c.mux.Unlock()
+	if ok {
+		return res.val, res.validators, true
+	}
+	if c.dir == "" {
+		return nil, Validators{}, false
+	}
+	entry, ok := c.readDisk(key)
 	if !ok {
-		return nil, ok
+		return nil, Validators{}, false
 	}
+	return entry.Val, entry.validators(), true
+}
 
-	return res.val, ok
+// Refresh 304 cevabından sonra kaydı tekrar taze sayar, veriyi değiştirmez.
+// 304 yeni validator getirdiyse boş olmayanlar eskilerin yerine geçer.
+func (c *Cache) Refresh(key string, validators Validators) bool {
+	now := time.Now()
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return false
+	}
+	res, ok := c.cacheEntries[key]
+	if ok {
+		res.createdAt = now
+		res.stale = false
+		res.validators = res.validators.merge(validators)
+		c.cacheEntries[key] = res
+	}
+	c.mux.Unlock()
 
+	if c.dir == "" {
+		return ok
+	}
+	entry, found := c.readDisk(key)
+	if !found {
+		return ok
+	}
+	merged := entry.validators().merge(validators)
+	c.writeDisk(key, entry.Val, merged, now)
+	if !ok {
+		c.mux.Lock()
+		if !c.closed {
+			c.addLocked(key, entry.Val, merged, now)
+		}
+		c.mux.Unlock()
+	}
+	return true
+}
+
+func (c *Cache) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.cacheEntries)
+}
+
+func (c *Cache) addLocked(key string, val []byte, validators Validators, createdAt time.Time) {
+	c.removeLocked(key)
+	elem := c.recency.PushFront(key)
+	c.cacheEntries[key] = CacheEntry{createdAt: createdAt, val: val, elem: elem, validators: validators}
+	c.totalBytes += len(val)
+	c.evictLocked()
+}
+
+func (c *Cache) removeLocked(key string) {
+	entry, ok := c.cacheEntries[key]
+	if !ok {
+		return
+	}
+	c.recency.Remove(entry.elem)
+	c.totalBytes -= len(entry.val)
+	delete(c.cacheEntries, key)
+}
+
+// En uzun süredir kullanılmayanları sınırlar içine girene kadar at
+func (c *Cache) evictLocked() {
+	for c.recency.Len() > 0 {
+		overEntries := c.maxEntries > 0 && len(c.cacheEntries) > c.maxEntries
+		overBytes := c.maxBytes > 0 && c.totalBytes > c.maxBytes
+		if !overEntries && !overBytes {
+			return
+		}
+		c.removeLocked(c.recency.Back().Value.(string))
+	}
 }
 
 func (c *Cache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
-	for range ticker.C {
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
 		c.mux.Lock()
 		now := time.Now()
 		for key, entry := range c.cacheEntries {
-			if now.Sub(entry.createdAt) > c.interval {
-				delete(c.cacheEntries, key)
+			if now.Sub(entry.createdAt) <= c.interval {
+				continue
+			}
+			if entry.validators.Empty() {
+				c.removeLocked(key)
+			} else if !entry.stale {
+				entry.stale = true
+				c.cacheEntries[key] = entry
 			}
 		}
 		c.mux.Unlock()
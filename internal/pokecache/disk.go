@@ -0,0 +1,84 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type diskEntry struct {
+	Key          string    `json:"key"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Val          []byte    `json:"val"`
+}
+
+func NewDiskCache(interval time.Duration, dir string, ttl time.Duration, opts ...Option) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := NewCache(interval, opts...)
+	c.dir = dir
+	c.diskTTL = ttl
+	return c, nil
+}
+
+func (c *Cache) diskPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) readDisk(key string) (diskEntry, bool) {
+	var entry diskEntry
+	data, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return entry, false
+	}
+	return entry, true
+}
+
+// Süresi dolan dosyaları silmiyoruz, offline modda hala işe yarıyorlar
+func (e diskEntry) expired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e diskEntry) validators() Validators {
+	return Validators{ETag: e.ETag, LastModified: e.LastModified}
+}
+
+func (c *Cache) writeDisk(key string, val []byte, validators Validators, createdAt time.Time) error {
+	data, err := json.Marshal(diskEntry{
+		Key:          key,
+		CreatedAt:    createdAt,
+		ExpiresAt:    createdAt.Add(c.diskTTL),
+		ETag:         validators.ETag,
+		LastModified: validators.LastModified,
+		Val:          val,
+	})
+	if err != nil {
+		return err
+	}
+	// Önce geçici dosyaya yazıp sonra taşıyoruz, yarım kalan dosya olmasın
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.diskPath(key))
+}
@@ -0,0 +1,101 @@
+package pokecache
+
+import "context"
+
+type call struct {
+	done    chan struct{}
+	val     []byte
+	err     error
+	waiters int
+	// İndirmeyi iptal eder, son bekleyen de ayrılınca çağrılır
+	cancel context.CancelFunc
+	// fn panik yaparsa bekleyenler aynı paniği kendi goroutine'lerinde tekrarlar
+	panicked any
+}
+
+type Stats struct {
+	Entries int
+	Bytes   int
+	Hits    int
+	Misses  int
+	Shared  int
+	// Şu an indirilmekte olan anahtarlar ve her birini bekleyen çağrı sayısı
+	InFlight map[string]int
+}
+
+// Do aynı anahtar için aynı anda gelen çağrıları birleştirir: fn sadece bir
+// kez çalışır, herkes onun sonucunu ya da hatasını alır. shared true ise sonuç
+// başka bir çağrının başlattığı indirmeden gelmiştir. fn hiçbir çağıranın
+// iptaline bağlı olmayan bir context alır; iptal edilen çağıran sadece
+// beklemeyi bırakır, indirme son bekleyen de ayrılınca iptal edilir.
+func (c *Cache) Do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) (val []byte, shared bool, err error) {
+	c.mux.Lock()
+	cl, shared := c.inFlight[key]
+	if shared {
+		cl.waiters++
+		c.shared++
+	} else {
+		// Değerleri koruyoruz ama iptali ilk çağırandan ayırıyoruz
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		cl = &call{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		c.inFlight[key] = cl
+		go c.run(fetchCtx, key, cl, fn)
+	}
+	c.mux.Unlock()
+
+	select {
+	case <-cl.done:
+		if cl.panicked != nil {
+			panic(cl.panicked)
+		}
+		return cl.val, shared, cl.err
+	case <-ctx.Done():
+		c.mux.Lock()
+		cl.waiters--
+		if cl.waiters == 0 {
+			// Kimse beklemiyor, yeni gelenler iptal edilmiş indirmeye katılmasın
+			c.forgetLocked(key, cl)
+			cl.cancel()
+		}
+		c.mux.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// run fn'i çalıştırır; fn panik yapsa bile bekleyenler serbest kalır
+func (c *Cache) run(ctx context.Context, key string, cl *call, fn func(context.Context) ([]byte, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			cl.panicked = r
+		}
+		c.mux.Lock()
+		c.forgetLocked(key, cl)
+		c.mux.Unlock()
+		cl.cancel()
+		close(cl.done)
+	}()
+	cl.val, cl.err = fn(ctx)
+}
+
+func (c *Cache) forgetLocked(key string, cl *call) {
+	if c.inFlight[key] == cl {
+		delete(c.inFlight, key)
+	}
+}
+
+func (c *Cache) Stats() Stats {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	s := Stats{
+		Entries:  len(c.cacheEntries),
+		Bytes:    c.totalBytes,
+		Hits:     c.hits,
+		Misses:   c.misses,
+		Shared:   c.shared,
+		InFlight: make(map[string]int, len(c.inFlight)),
+	}
+	for key, cl := range c.inFlight {
+		s.InFlight[key] = cl.waiters
+	}
+	return s
+}
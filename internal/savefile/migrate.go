@@ -0,0 +1,211 @@
+package savefile
+
+import (
+	"fmt"
+	"time"
+)
+
+// Migration bir kayıt belgesini From sürümünden From+1 sürümüne taşır.
+// Migrate fonksiyonları ham JSON belgesi üzerinde çalışır ve bir kere
+// yayınlandıktan sonra değiştirilmemelidir, golden testler bunu kontrol eder.
+type Migration struct {
+	From        int
+	Description string
+	Migrate     func(doc map[string]any) error
+}
+
+var migrations = []Migration{
+	{
+		From:        1,
+		Description: "store caught pokemon in a stable model instead of the raw PokeAPI response",
+		Migrate:     migrateV1ToV2,
+	},
+	{
+		From:        2,
+		Description: "add a bag of Poke Balls, starting with the starter set",
+		Migrate:     migrateV2ToV3,
+	},
+	{
+		From:        3,
+		Description: "record the level of caught pokemon, level 5 for older catches",
+		Migrate:     migrateV3ToV4,
+	},
+	{
+		From:        4,
+		Description: "track the trainer's current location, starting where the latest catch happened",
+		Migrate:     migrateV4ToV5,
+	},
+	{
+		From:        5,
+		Description: "give caught pokemon IVs for battles, the average 15 for older catches",
+		Migrate:     migrateV5ToV6,
+	},
+}
+
+func Migrations() []Migration {
+	return migrations
+}
+
+func docVersion(doc map[string]any) (int, error) {
+	v, ok := doc["version"].(float64)
+	if !ok || v < 1 || v != float64(int(v)) {
+		return 0, fmt.Errorf("missing or invalid version")
+	}
+	return int(v), nil
+}
+
+// migrate belgeyi sırayla güncel sürüme taşır ve uygulanan adımları döner
+func migrate(doc map[string]any) ([]Migration, error) {
+	version, err := docVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	for version < CurrentVersion {
+		step, ok := findMigration(version)
+		if !ok {
+			return applied, fmt.Errorf("no migration from save version %d", version)
+		}
+		if err := step.Migrate(doc); err != nil {
+			return applied, fmt.Errorf("migrating save from version %d: %w", version, err)
+		}
+		version++
+		doc["version"] = float64(version)
+		applied = append(applied, step)
+	}
+	return applied, nil
+}
+
+func findMigration(from int) (Migration, bool) {
+	for _, m := range migrations {
+		if m.From == from {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+func migrateV1ToV2(doc map[string]any) error {
+	caught, _ := doc["caught"].(map[string]any)
+	for key, raw := range caught {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("caught pokemon %q is not an object", key)
+		}
+		apiPokemon, ok := entry["pokemon"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("caught pokemon %q has no pokemon data", key)
+		}
+
+		stats := []any{}
+		for _, s := range asList(apiPokemon["stats"]) {
+			stat, _ := s.(map[string]any)
+			stats = append(stats, map[string]any{
+				"name": nestedName(stat["stat"]),
+				"base": numberOr(stat["base_stat"]),
+			})
+		}
+		types := []any{}
+		for _, t := range asList(apiPokemon["types"]) {
+			typ, _ := t.(map[string]any)
+			types = append(types, nestedName(typ["type"]))
+		}
+
+		entry["pokemon"] = map[string]any{
+			"id":              numberOr(apiPokemon["id"]),
+			"name":            apiPokemon["name"],
+			"base_experience": numberOr(apiPokemon["base_experience"]),
+			"height":          numberOr(apiPokemon["height"]),
+			"weight":          numberOr(apiPokemon["weight"]),
+			"stats":           stats,
+			"types":           types,
+		}
+	}
+	return nil
+}
+
+func migrateV2ToV3(doc map[string]any) error {
+	if _, ok := doc["bag"]; ok {
+		return nil
+	}
+	doc["bag"] = map[string]any{
+		"poke-ball":  float64(10),
+		"great-ball": float64(5),
+		"ultra-ball": float64(2),
+	}
+	return nil
+}
+
+func migrateV3ToV4(doc map[string]any) error {
+	caught, _ := doc["caught"].(map[string]any)
+	for key, raw := range caught {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("caught pokemon %q is not an object", key)
+		}
+		if _, ok := entry["level"]; !ok {
+			entry["level"] = float64(5)
+		}
+	}
+	return nil
+}
+
+func migrateV4ToV5(doc map[string]any) error {
+	if _, ok := doc["location"]; ok {
+		return nil
+	}
+	location := ""
+	var latest time.Time
+	caught, _ := doc["caught"].(map[string]any)
+	for _, raw := range caught {
+		entry, _ := raw.(map[string]any)
+		area, _ := entry["location"].(string)
+		text, _ := entry["caught_at"].(string)
+		caughtAt, err := time.Parse(time.RFC3339Nano, text)
+		if area == "" || err != nil {
+			continue
+		}
+		if location == "" || caughtAt.After(latest) {
+			location, latest = area, caughtAt
+		}
+	}
+	doc["location"] = location
+	return nil
+}
+
+func migrateV5ToV6(doc map[string]any) error {
+	caught, _ := doc["caught"].(map[string]any)
+	for key, raw := range caught {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("caught pokemon %q is not an object", key)
+		}
+		if _, ok := entry["ivs"]; ok {
+			continue
+		}
+		ivs := map[string]any{}
+		for _, stat := range []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"} {
+			ivs[stat] = float64(15)
+		}
+		entry["ivs"] = ivs
+	}
+	return nil
+}
+
+func asList(v any) []any {
+	list, _ := v.([]any)
+	return list
+}
+
+func nestedName(v any) any {
+	m, _ := v.(map[string]any)
+	if m == nil {
+		return ""
+	}
+	return m["name"]
+}
+
+func numberOr(v any) float64 {
+	f, _ := v.(float64)
+	return f
+}
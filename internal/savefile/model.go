@@ -0,0 +1,37 @@
+package savefile
+
+import "github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+
+// Pokemon kayıtta tuttuğumuz sabit model. PokeAPI'nin cevabı değişse de
+// kayıt dosyası bundan etkilenmesin diye ham Pokemon struct'ını saklamıyoruz.
+type Pokemon struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	BaseExperience int      `json:"base_experience"`
+	Height         int      `json:"height"`
+	Weight         int      `json:"weight"`
+	Stats          []Stat   `json:"stats"`
+	Types          []string `json:"types"`
+}
+
+type Stat struct {
+	Name string `json:"name"`
+	Base int    `json:"base"`
+}
+
+func FromAPI(p pokeapi.Pokemon) Pokemon {
+	res := Pokemon{
+		ID:             p.ID,
+		Name:           p.Name,
+		BaseExperience: p.BaseExperience,
+		Height:         p.Height,
+		Weight:         p.Weight,
+	}
+	for _, s := range p.Stats {
+		res.Stats = append(res.Stats, Stat{Name: s.Stat.Name, Base: s.BaseStat})
+	}
+	for _, t := range p.Types {
+		res.Types = append(res.Types, t.Type.Name)
+	}
+	return res
+}
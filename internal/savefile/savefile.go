@@ -0,0 +1,186 @@
+package savefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const CurrentVersion = 6
+
+var ErrCorrupt = errors.New("save file is corrupt")
+
+type File struct {
+	Version int               `json:"version"`
+	SavedAt time.Time         `json:"saved_at"`
+	Caught  map[string]Caught `json:"caught"`
+	// Eşya adı (PokeAPI item adı) -> adet
+	Bag map[string]int `json:"bag"`
+	// Antrenörün şu an bulunduğu location-area, boşsa henüz bir yere gitmedi
+	Location string `json:"location"`
+}
+
+type Caught struct {
+	Pokemon  Pokemon   `json:"pokemon"`
+	CaughtAt time.Time `json:"caught_at"`
+	Location string    `json:"location,omitempty"`
+	Level    int       `json:"level"`
+	// Stat adı (hp, attack, ...) -> değer. IV'ler yakalanınca belirlenir, EV'ler savaşla artar.
+	IVs map[string]int `json:"ivs"`
+	EVs map[string]int `json:"evs,omitempty"`
+}
+
+// Report --check modunda kayıt dosyasına yazmadan neler yapılacağını anlatır
+type Report struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Steps       []Migration
+	Caught      int
+}
+
+func New() *File {
+	return &File{
+		Version: CurrentVersion,
+		Caught:  make(map[string]Caught),
+		Bag:     StarterBag(),
+	}
+}
+
+// Yeni başlayan her antrenörün çantası
+func StarterBag() map[string]int {
+	return map[string]int{
+		"poke-ball":  10,
+		"great-ball": 5,
+		"ultra-ball": 2,
+	}
+}
+
+// Load dosya yoksa boş bir kayıt döner. Dosya bozuksa yanına yedeğini alır,
+// boş bir kayıt ve ErrCorrupt döner ki bozuk dosyanın üstüne yazılmasın.
+// Eski sürüm kayıtlar güncel modele taşınır, orijinali .v<sürüm>.bak olarak saklanır.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f, report, err := decode(data)
+	if errors.Is(err, ErrCorrupt) {
+		backup, backupErr := backupCorrupt(path)
+		if backupErr != nil {
+			return nil, fmt.Errorf("%w and could not be backed up: %v", ErrCorrupt, backupErr)
+		}
+		return New(), fmt.Errorf("%w, moved it to %s", ErrCorrupt, backup)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Steps) > 0 {
+		backup := fmt.Sprintf("%s.v%d.bak", path, report.FromVersion)
+		if err := os.WriteFile(backup, data, 0o644); err != nil {
+			return nil, fmt.Errorf("could not back up save before migrating: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// Check Load gibi okur ve migration'ları bellekte çalıştırır ama hiçbir dosyaya yazmaz
+func Check(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Report{Path: path}, err
+	}
+	f, report, err := decode(data)
+	report.Path = path
+	if err != nil {
+		return report, err
+	}
+	report.Caught = len(f.Caught)
+	return report, nil
+}
+
+func decode(data []byte) (*File, Report, error) {
+	var report Report
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil || doc == nil {
+		return nil, report, ErrCorrupt
+	}
+	version, err := docVersion(doc)
+	if err != nil {
+		return nil, report, ErrCorrupt
+	}
+	report.FromVersion = version
+	report.ToVersion = version
+	if version > CurrentVersion {
+		return nil, report, fmt.Errorf("save file version %d is newer than this Pokedex supports (%d)", version, CurrentVersion)
+	}
+
+	steps, err := migrate(doc)
+	report.Steps = steps
+	if err != nil {
+		return nil, report, err
+	}
+	report.ToVersion = CurrentVersion
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, report, err
+	}
+	// New() kullanmıyoruz, yoksa başlangıç çantası boşalmış çantanın üstüne birleşir
+	f := &File{}
+	if err := json.Unmarshal(migrated, f); err != nil {
+		return nil, report, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if f.Caught == nil {
+		f.Caught = make(map[string]Caught)
+	}
+	if f.Bag == nil {
+		f.Bag = make(map[string]int)
+	}
+	return f, report, nil
+}
+
+// Save önce geçici dosyaya yazar sonra taşır, yarıda kalan yazma eski kaydı bozmaz
+func Save(path string, f *File) error {
+	f.Version = CurrentVersion
+	f.SavedAt = time.Now()
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func backupCorrupt(path string) (string, error) {
+	backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	return backup, os.Rename(path, backup)
+}
@@ -0,0 +1,141 @@
+package typechart
+
+import "sort"
+
+// Tür etkileşim tablosu. PokeAPI'nin type endpoint'indeki damage_relations
+// ile doldurulur, sadece yüklenen türler için bilgi verir.
+// https://bulbapedia.bulbagarden.net/wiki/Type
+
+// DamageRelations bir türün saldırırken (To) ve savunurken (From) 1x dışındaki çarpanları
+type DamageRelations struct {
+	DoubleDamageTo   []string
+	HalfDamageTo     []string
+	NoDamageTo       []string
+	DoubleDamageFrom []string
+	HalfDamageFrom   []string
+	NoDamageFrom     []string
+}
+
+type Chart struct {
+	// attack[saldıran][savunan], yoksa 1x
+	attack map[string]map[string]float64
+	loaded map[string]bool
+}
+
+func New() *Chart {
+	return &Chart{
+		attack: make(map[string]map[string]float64),
+		loaded: make(map[string]bool),
+	}
+}
+
+func (c *Chart) Add(name string, r DamageRelations) {
+	c.loaded[name] = true
+	for _, t := range r.DoubleDamageTo {
+		c.set(name, t, 2)
+	}
+	for _, t := range r.HalfDamageTo {
+		c.set(name, t, 0.5)
+	}
+	for _, t := range r.NoDamageTo {
+		c.set(name, t, 0)
+	}
+	for _, t := range r.DoubleDamageFrom {
+		c.set(t, name, 2)
+	}
+	for _, t := range r.HalfDamageFrom {
+		c.set(t, name, 0.5)
+	}
+	for _, t := range r.NoDamageFrom {
+		c.set(t, name, 0)
+	}
+}
+
+func (c *Chart) set(attacking, defending string, multiplier float64) {
+	if c.attack[attacking] == nil {
+		c.attack[attacking] = make(map[string]float64)
+	}
+	c.attack[attacking][defending] = multiplier
+}
+
+// Has türün ilişkilerinin yüklenip yüklenmediğini söyler
+func (c *Chart) Has(name string) bool {
+	return c.loaded[name]
+}
+
+func (c *Chart) multiplier(attacking, defending string) float64 {
+	if m, ok := c.attack[attacking][defending]; ok {
+		return m
+	}
+	return 1
+}
+
+// Effectiveness saldıran türün bir ya da iki türlü savunana çarpanı, ör. ice -> dragon/flying 4x
+func (c *Chart) Effectiveness(attacking string, defending ...string) float64 {
+	m := 1.0
+	for _, d := range defending {
+		m *= c.multiplier(attacking, d)
+	}
+	return m
+}
+
+// Defensive savunan türlere 1x dışında hasar veren saldırı türlerini ve çarpanlarını döner.
+// Savunan türlerin yüklenmiş olması yeterli.
+func (c *Chart) Defensive(defending ...string) map[string]float64 {
+	attackers := make(map[string]bool)
+	for attacking, row := range c.attack {
+		for _, d := range defending {
+			if _, ok := row[d]; ok {
+				attackers[attacking] = true
+			}
+		}
+	}
+	result := make(map[string]float64)
+	for attacking := range attackers {
+		if m := c.Effectiveness(attacking, defending...); m != 1 {
+			result[attacking] = m
+		}
+	}
+	return result
+}
+
+// Offensive her savunan tür için saldıran türlerden en iyisinin çarpanını döner,
+// 1x olanlar hariç. Saldıran türlerin yüklenmiş olması yeterli.
+func (c *Chart) Offensive(attacking ...string) map[string]float64 {
+	best := make(map[string]float64)
+	for _, a := range attacking {
+		for defending := range c.attack[a] {
+			if _, ok := best[defending]; !ok {
+				best[defending] = 0
+			}
+		}
+	}
+	for defending := range best {
+		m := 0.0
+		for _, a := range attacking {
+			m = max(m, c.multiplier(a, defending))
+		}
+		best[defending] = m
+	}
+	for defending, m := range best {
+		if m == 1 {
+			delete(best, defending)
+		}
+	}
+	return best
+}
+
+// Group çarpanları büyükten küçüğe, her çarpandaki türleri alfabetik sıralı döner
+func Group(multipliers map[string]float64) ([]float64, map[float64][]string) {
+	groups := make(map[float64][]string)
+	for t, m := range multipliers {
+		groups[m] = append(groups[m], t)
+	}
+	keys := make([]float64, 0, len(groups))
+	for m, types := range groups {
+		sort.Strings(types)
+		keys = append(keys, m)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(keys)))
+	return keys, groups
+}
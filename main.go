@@ -1,27 +1,64 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/chzyer/readline"
 )
 
+var rl *readline.Instance
+
+const replPrompt = "Pokedex > "
+
 func main() {
-	rl, err := readline.NewEx(&readline.Config{
-		Prompt:          "Pokedex > ",
-		HistoryFile:     "pokedex_history.txt", // Komutlar bu dosyaya kaydedilir
+	if err := ensureDefaultProfile(); err != nil {
+		log.Fatal(err)
+	}
+	cliArgs = os.Args[1:]
+	s, err := loadSettings(cliArgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if s.CheckSave {
+		if err := checkSave(s.SaveFile); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	setup(s)
+	if err := loadSave(s.SaveFile); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeActiveProfile(s.Profile); err != nil {
+		log.Fatal(err)
+	}
+
+	rl, err = readline.NewEx(&readline.Config{
+		Prompt:          replPrompt,
+		HistoryFile:     s.HistoryFile, // Komutlar aktif profilin geçmiş dosyasına kaydedilir
 		InterruptPrompt: "^C",
 		EOFPrompt:       "exit",
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer rl.Close()
+	defer func() { cache.Close() }()
 
 	for {
 		command, err := rl.Readline()
-		if err != nil { // Ctrl+C veya Ctrl+D (EOF) durumunda döngüden çıkar
+		if errors.Is(err, readline.ErrInterrupt) { // Ctrl+C sadece satırı temizler
+			continue
+		}
+		if err != nil { // Ctrl+D (EOF) durumunda döngüden çıkar
 			break
 		}
 
@@ -34,11 +71,39 @@ func main() {
 		res, ok := commands[real_command]
 		if !ok {
 			fmt.Println("Unknown command")
+			continue
 		}
 		args := clean_command[1:]
-		err = res.callback(&res.config, args...)
+		err = runCommand(res, activeSettings.Timeout.Duration(), args)
+		if errors.Is(err, errExit) {
+			break
+		}
 		if err != nil {
 			fmt.Println(err)
 		}
 	}
+
+	if err := persist(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// runCommand komut çalışırken gelen Ctrl+C'yi programı kapatmak yerine
+// komutun context'ini iptal etmek için kullanır. Zaman aşımı komutun tamamına
+// değil client'ın her denemesine uygulanır, timeout sadece mesaj için. Tekrar
+// denemelerle komut bundan uzun sürebildiği için mesajda geçen süreyi de veriyoruz.
+func runCommand(res cliCommand, timeout time.Duration, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start := time.Now()
+	err := res.callback(ctx, res.config, args...)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errors.New("Cancelled.")
+	case errors.Is(err, context.DeadlineExceeded):
+		elapsed := time.Since(start).Round(time.Millisecond)
+		return fmt.Errorf("Timed out after %s, each attempt may take up to %s.", elapsed, timeout)
+	}
+	return err
 }
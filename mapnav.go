@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+)
+
+const mapPageSize = 20
+
+// Şu an gezilen map seviyesi: "" tüm location-area'lar, "regions",
+// "kanto" ya da "kanto/viridian-forest"
+var mapLevel string
+
+// Her seviyenin kendi sayfa imleci var, "" seviyesi için mapConfig kullanılır
+var mapLevels = map[string]*config{}
+
+func resetMapLevels() {
+	mapLevel = ""
+	mapLevels = map[string]*config{}
+}
+
+func parseMapLevel(arg string) (string, error) {
+	level := strings.Trim(strings.ToLower(arg), "/")
+	if level == "" || level == "areas" {
+		return "", nil
+	}
+	parts := strings.Split(level, "/")
+	if len(parts) > 2 || parts[0] == "" || parts[len(parts)-1] == "" {
+		return "", fmt.Errorf("usage: map [regions | areas | <region> | <region>/<location>]")
+	}
+	return level, nil
+}
+
+// browseMap seviyeyi değiştirir (argüman verilmişse) ve o seviyenin bir sonraki
+// ya da bir önceki sayfasını gösterir. Seviye sadece sayfa başarıyla gelince değişir.
+func browseMap(ctx context.Context, cfg *config, back bool, args ...string) error {
+	level := mapLevel
+	if len(args) > 0 {
+		var err error
+		level, err = parseMapLevel(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	cur := cfg
+	if level != "" {
+		var ok bool
+		cur, ok = mapLevels[level]
+		if !ok {
+			cur = &config{Next: firstMapPage(level)}
+		}
+	}
+
+	cursor := cur.Next
+	if back {
+		cursor = cur.Previous
+	}
+	if cursor == "" {
+		mapLevel = level
+		if back {
+			fmt.Println("you're on the first page")
+		} else {
+			fmt.Println("you're on the last page")
+		}
+		return nil
+	}
+
+	if err := showMapPage(ctx, level, cur, cursor); err != nil {
+		return err
+	}
+	if level != "" {
+		mapLevels[level] = cur
+	}
+	mapLevel = level
+	return nil
+}
+
+func firstMapPage(level string) string {
+	if level == "regions" {
+		return client.RegionsURL()
+	}
+	return "0"
+}
+
+func showMapPage(ctx context.Context, level string, cur *config, cursor string) error {
+	switch level {
+	case "":
+		result, err := client.ListLocationAreas(ctx, cursor)
+		if err != nil {
+			return err
+		}
+		printLocationAreas(cur, result)
+		return nil
+	case "regions":
+		result, err := client.ListRegions(ctx, cursor)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Regions:")
+		for _, region := range result.Results {
+			fmt.Println(region.Name)
+		}
+		cur.Next = result.Next
+		cur.Previous = result.Previous
+		return nil
+	}
+
+	entries, err := mapLevelEntries(ctx, level)
+	if err != nil {
+		return err
+	}
+	page, err := pageEntries(entries, cursor, cur)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(level, "/") {
+		fmt.Printf("Areas in %s:\n", level)
+	} else {
+		fmt.Printf("Locations in %s:\n", level)
+	}
+	if len(page) == 0 {
+		fmt.Println("(none)")
+	}
+	for _, entry := range page {
+		fmt.Println(entry.Name)
+	}
+	return nil
+}
+
+// mapLevelEntries bölgedeki location'ları ya da location'daki alanları döner.
+// Bunlar API'de sayfalı değil, sayfalamayı pageEntries yapıyor.
+func mapLevelEntries(ctx context.Context, level string) ([]pokeapi.NamedResource, error) {
+	regionName, locationName, nested := strings.Cut(level, "/")
+	if !nested {
+		region, err := client.GetRegion(ctx, regionName)
+		if err != nil {
+			return nil, err
+		}
+		return region.Locations, nil
+	}
+	location, err := client.GetLocation(ctx, locationName)
+	if err != nil {
+		return nil, err
+	}
+	if location.Region.Name != regionName {
+		return nil, fmt.Errorf("%s is not in %s.", locationName, regionName)
+	}
+	return location.Areas, nil
+}
+
+// pageEntries cursor'daki offset'ten bir sayfa döner ve cfg'yi API'nin
+// next/previous linkleri gibi sonraki ve önceki sayfaya ayarlar
+func pageEntries(entries []pokeapi.NamedResource, cursor string, cfg *config) ([]pokeapi.NamedResource, error) {
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return nil, fmt.Errorf("invalid map page %q", cursor)
+	}
+	offset = min(offset, len(entries))
+	end := min(offset+mapPageSize, len(entries))
+
+	cfg.Next = ""
+	if end < len(entries) {
+		cfg.Next = strconv.Itoa(end)
+	}
+	cfg.Previous = ""
+	if offset > 0 {
+		cfg.Previous = strconv.Itoa(max(offset-mapPageSize, 0))
+	}
+	return entries[offset:end], nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/typechart"
+)
+
+// Tür tablosu, türler gerektikçe client üzerinden (cache ve diskten) yüklenir
+var typeChart = typechart.New()
+
+func loadTypes(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		if typeChart.Has(name) {
+			continue
+		}
+		t, err := client.GetType(ctx, name)
+		if err != nil {
+			return err
+		}
+		typeChart.Add(t.Name, damageRelations(t))
+	}
+	return nil
+}
+
+func damageRelations(t pokeapi.Type) typechart.DamageRelations {
+	names := func(resources []pokeapi.NamedResource) []string {
+		var result []string
+		for _, r := range resources {
+			result = append(result, r.Name)
+		}
+		return result
+	}
+	dr := t.DamageRelations
+	return typechart.DamageRelations{
+		DoubleDamageTo:   names(dr.DoubleDamageTo),
+		HalfDamageTo:     names(dr.HalfDamageTo),
+		NoDamageTo:       names(dr.NoDamageTo),
+		DoubleDamageFrom: names(dr.DoubleDamageFrom),
+		HalfDamageFrom:   names(dr.HalfDamageFrom),
+		NoDamageFrom:     names(dr.NoDamageFrom),
+	}
+}
+
+type matchupSide struct {
+	Label string
+	Types []string
+}
+
+// resolveMatchupSide argüman bir türse onu, değilse pokemonun türlerini kullanır
+func resolveMatchupSide(ctx context.Context, name string) (matchupSide, error) {
+	name = strings.ToLower(name)
+	t, err := client.GetType(ctx, name)
+	if err == nil {
+		typeChart.Add(t.Name, damageRelations(t))
+		return matchupSide{Label: t.Name, Types: []string{t.Name}}, nil
+	}
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return matchupSide{}, err
+	}
+	p, err := client.GetPokemon(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return matchupSide{}, fmt.Errorf("%s is neither a pokemon nor a type.", name)
+	}
+	if err != nil {
+		return matchupSide{}, err
+	}
+	side := matchupSide{}
+	for _, t := range p.Types {
+		side.Types = append(side.Types, t.Type.Name)
+	}
+	side.Label = fmt.Sprintf("%s (%s)", p.Name, strings.Join(side.Types, "/"))
+	return side, loadTypes(ctx, side.Types...)
+}
+
+func formatMultiplier(m float64) string {
+	return strconv.FormatFloat(m, 'f', -1, 64) + "x"
+}
+
+func printMultiplierGroups(title string, multipliers map[string]float64, keep func(float64) bool) {
+	keys, groups := typechart.Group(multipliers)
+	printed := false
+	for _, m := range keys {
+		if !keep(m) {
+			continue
+		}
+		if !printed {
+			fmt.Println(title + ":")
+			printed = true
+		}
+		fmt.Printf("  %s: %s\n", formatMultiplier(m), strings.Join(groups[m], ", "))
+	}
+	if !printed {
+		fmt.Println(title + ": none")
+	}
+}
+
+func commandMatchup(ctx context.Context, cfg *config, args ...string) error {
+	usage := errors.New("usage: matchup <pokemon|type> [vs <pokemon|type>]")
+	if len(args) != 1 && (len(args) != 3 || args[1] != "vs") {
+		return usage
+	}
+	side, err := resolveMatchupSide(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		fmt.Println(side.Label)
+		defensive := typeChart.Defensive(side.Types...)
+		printMultiplierGroups("Weaknesses", defensive, func(m float64) bool { return m > 1 })
+		printMultiplierGroups("Resistances", defensive, func(m float64) bool { return m > 0 && m < 1 })
+		printMultiplierGroups("Immunities", defensive, func(m float64) bool { return m == 0 })
+		offensive := typeChart.Offensive(side.Types...)
+		printMultiplierGroups("Super effective against", offensive, func(m float64) bool { return m > 1 })
+		printMultiplierGroups("Not very effective against", offensive, func(m float64) bool { return m > 0 && m < 1 })
+		printMultiplierGroups("No effect on", offensive, func(m float64) bool { return m == 0 })
+		return nil
+	}
+
+	other, err := resolveMatchupSide(ctx, args[2])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s vs %s\n", side.Label, other.Label)
+	for _, pair := range [][2]matchupSide{{side, other}, {other, side}} {
+		attacker, defender := pair[0], pair[1]
+		for _, t := range attacker.Types {
+			m := typeChart.Effectiveness(t, defender.Types...)
+			fmt.Printf("  %s -> %s: %s\n", t, strings.Join(defender.Types, "/"), formatMultiplier(m))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/savefile"
+)
+
+// pokemonInfo inspect ve lookup'ın ortak gösterdiği bilgiler. Sadece kayıttan
+// gelen pokemonlarda species alanları boş kalır ve yazdırılmaz.
+type pokemonInfo struct {
+	Pokemon    savefile.Pokemon
+	DexNumber  int
+	Genus      string
+	FlavorText string
+	Abilities  []abilityInfo
+	EggGroups  []string
+	// Sekizde kaçının dişi olduğu, -1 cinsiyetsiz
+	GenderRate int
+	HasSpecies bool
+}
+
+type abilityInfo struct {
+	Name   string
+	Hidden bool
+}
+
+func newPokemonInfo(p pokeapi.Pokemon, species pokeapi.PokemonSpecies, versions []string) pokemonInfo {
+	info := pokemonInfo{
+		Pokemon:    savefile.FromAPI(p),
+		DexNumber:  species.NationalDexNumber(),
+		Genus:      species.EnglishGenus(),
+		FlavorText: species.FlavorText(versions...),
+		GenderRate: species.GenderRate,
+		HasSpecies: true,
+	}
+	for _, a := range p.Abilities {
+		info.Abilities = append(info.Abilities, abilityInfo{Name: a.Ability.Name, Hidden: a.IsHidden})
+	}
+	for _, g := range species.EggGroups {
+		info.EggGroups = append(info.EggGroups, g.Name)
+	}
+	return info
+}
+
+func savedPokemonInfo(p savefile.Pokemon) pokemonInfo {
+	return pokemonInfo{Pokemon: p, DexNumber: p.ID}
+}
+
+func printPokemonInfo(info pokemonInfo) {
+	res := info.Pokemon
+	fmt.Printf("Name: %s (#%03d)\n", res.Name, info.DexNumber)
+	if info.Genus != "" {
+		fmt.Printf("Genus: %s\n", info.Genus)
+	}
+	if info.FlavorText != "" {
+		fmt.Printf("%q\n", info.FlavorText)
+	}
+	fmt.Printf("Types: %s\n", strings.Join(res.Types, ", "))
+	if len(info.Abilities) > 0 {
+		var names []string
+		for _, a := range info.Abilities {
+			if a.Hidden {
+				names = append(names, a.Name+" (hidden)")
+			} else {
+				names = append(names, a.Name)
+			}
+		}
+		fmt.Printf("Abilities: %s\n", strings.Join(names, ", "))
+	}
+	// PokeAPI boyu desimetre, ağırlığı hektogram olarak veriyor
+	fmt.Printf("Height: %.1f m\n", float64(res.Height)/10)
+	fmt.Printf("Weight: %.1f kg\n", float64(res.Weight)/10)
+	fmt.Printf("Base stats:\n")
+	total := 0
+	for _, val := range res.Stats {
+		fmt.Printf("  - %s: %v\n", val.Name, val.Base)
+		total += val.Base
+	}
+	fmt.Printf("  - total: %d\n", total)
+	if len(info.EggGroups) > 0 {
+		fmt.Printf("Egg groups: %s\n", strings.Join(info.EggGroups, ", "))
+	}
+	if info.HasSpecies {
+		fmt.Printf("Gender: %s\n", genderRatio(info.GenderRate))
+	}
+}
+
+func genderRatio(rate int) string {
+	if rate < 0 {
+		return "genderless"
+	}
+	female := float64(rate) / 8 * 100
+	return fmt.Sprintf("%.1f%% male, %.1f%% female", 100-female, female)
+}
+
+func commandLookup(ctx context.Context, cfg *config, args ...string) error {
+	name, sections, err := parseInspectArgs(args)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("usage: lookup <pokemon|id> %s", inspectFlags)
+	}
+	result, err := client.GetPokemon(ctx, name)
+	if err != nil {
+		return err
+	}
+	species, err := client.GetPokemonSpecies(ctx, result.Species.Name)
+	if err != nil {
+		return err
+	}
+	v, err := currentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	var versions []string
+	if v != nil {
+		versions = v.Versions
+	}
+	printPokemonInfo(newPokemonInfo(result, species, versions))
+	if _, ok := saveData.Caught[result.Name]; ok {
+		fmt.Println("You have caught this pokemon.")
+	}
+	if sections.any() {
+		return printInspectSections(ctx, result, sections)
+	}
+	return inspectVersion(ctx, result.Name)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const defaultProfile = "default"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Komut satırı argümanları, profil değişince ayarları yeniden okumak için
+var cliArgs []string
+
+func profilesDir() string {
+	dir := dataDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "profiles")
+}
+
+func profileDir(name string) string {
+	dir := profilesDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, name)
+}
+
+func activeProfilePath() string {
+	return filepath.Join(dataDir(), "active_profile")
+}
+
+func validProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, - and _", name)
+	}
+	return nil
+}
+
+func readActiveProfile() string {
+	if dataDir() == "" {
+		return defaultProfile
+	}
+	data, err := os.ReadFile(activeProfilePath())
+	if err != nil {
+		return defaultProfile
+	}
+	name := strings.TrimSpace(string(data))
+	if validProfileName(name) != nil {
+		return defaultProfile
+	}
+	return name
+}
+
+func writeActiveProfile(name string) error {
+	if dataDir() == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dataDir(), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(activeProfilePath(), []byte(name+"\n"), 0o644)
+}
+
+func profileExists(name string) bool {
+	info, err := os.Stat(profileDir(name))
+	return err == nil && info.IsDir()
+}
+
+func createProfile(name string) error {
+	if err := validProfileName(name); err != nil {
+		return err
+	}
+	if profilesDir() == "" {
+		return errors.New("no data directory available for profiles")
+	}
+	return os.MkdirAll(profileDir(name), 0o755)
+}
+
+// ensureDefaultProfile profiller gelmeden önceki tek kayıt dosyasını ve
+// komut geçmişini default profile taşır
+func ensureDefaultProfile() error {
+	if profilesDir() == "" || profileExists(defaultProfile) {
+		return nil
+	}
+	if err := createProfile(defaultProfile); err != nil {
+		return err
+	}
+	legacySave := filepath.Join(dataDir(), "save.json")
+	if _, err := os.Stat(legacySave); err == nil {
+		if err := os.Rename(legacySave, filepath.Join(profileDir(defaultProfile), "save.json")); err != nil {
+			return err
+		}
+	}
+	if err := copyFile("pokedex_history.txt", filepath.Join(profileDir(defaultProfile), "history.txt")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func listProfiles() ([]string, error) {
+	entries, err := os.ReadDir(profilesDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && validProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// switchProfile mevcut kaydı yazar, yeni profilin ayarlarını, kaydını ve
+// komut geçmişini yükler. Yeni kayıt okunamazsa hiçbir şey değişmez, yoksa
+// eski antrenörün verisi yeni profilin kaydının üstüne yazılırdı.
+func switchProfile(name string) error {
+	if err := persist(); err != nil {
+		return err
+	}
+	s, err := loadSettingsFor(cliArgs, name)
+	if err != nil {
+		return err
+	}
+	f, err := readSave(s.SaveFile)
+	if err != nil {
+		return err
+	}
+	if err := writeActiveProfile(name); err != nil {
+		return err
+	}
+	if cache != nil {
+		cache.Close()
+	}
+	setup(s)
+	savePath = s.SaveFile
+	saveData = f
+	wild = nil
+	if rl != nil && s.HistoryFile != "" {
+		rl.SetHistoryPath(s.HistoryFile)
+	}
+	return nil
+}
+
+func commandProfile(ctx context.Context, cfg *config, args ...string) error {
+	usage := errors.New("usage: profile list | profile new <name> | profile use <name> | profile delete <name>")
+	if len(args) == 0 {
+		return usage
+	}
+	switch args[0] {
+	case "list":
+		names, err := listProfiles()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			marker := " "
+			if name == activeSettings.Profile {
+				marker = "*"
+			}
+			fmt.Printf(" %s %s\n", marker, name)
+		}
+		return nil
+	case "new":
+		if len(args) < 2 {
+			return usage
+		}
+		if profileExists(args[1]) {
+			return fmt.Errorf("profile %q already exists", args[1])
+		}
+		if err := createProfile(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Created profile %s. Switch to it with: profile use %s\n", args[1], args[1])
+		return nil
+	case "use":
+		if len(args) < 2 {
+			return usage
+		}
+		if !profileExists(args[1]) {
+			return fmt.Errorf("profile %q does not exist", args[1])
+		}
+		if err := switchProfile(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Now playing as %s.\n", activeSettings.Profile)
+		return nil
+	case "delete":
+		if len(args) < 2 {
+			return usage
+		}
+		name := args[1]
+		if err := validProfileName(name); err != nil {
+			return err
+		}
+		if name == activeSettings.Profile {
+			return fmt.Errorf("cannot delete the active profile, switch to another one first")
+		}
+		if !profileExists(name) {
+			return fmt.Errorf("profile %q does not exist", name)
+		}
+		if err := os.RemoveAll(profileDir(name)); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile %s.\n", name)
+		return nil
+	}
+	return usage
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -1,11 +1,30 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ardamertdedeoglu/pokedexcli/internal/battle"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/capture"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
 	"github.com/ardamertdedeoglu/pokedexcli/internal/pokecache"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/savefile"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/typechart"
 )
 
 func TestCleanInput(t *testing.T) {
@@ -57,6 +76,7 @@ func TestAddGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := pokecache.NewCache(interval)
+			defer cache.Close()
 			cache.Add(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -75,6 +95,7 @@ func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = baseTime + 4*time.Millisecond
 	cache := pokecache.NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -91,3 +112,1682 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestDiskCache(t *testing.T) {
+	const interval = 5 * time.Millisecond
+	dir := t.TempDir()
+	cache, err := pokecache.NewDiskCache(interval, dir, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(interval * 3)
+
+	// Yeni bir oturum gibi sadece diskten okunmalı
+	restarted, err := pokecache.NewDiskCache(interval, dir, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer restarted.Close()
+	val, ok := restarted.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value")
+		return
+	}
+
+	expired, err := pokecache.NewDiskCache(interval, dir, -time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer expired.Close()
+	expired.Add("https://example.com/old", []byte("olddata"))
+	fresh, _ := pokecache.NewDiskCache(interval, dir, time.Hour)
+	defer fresh.Close()
+	if _, ok := fresh.Get("https://example.com/old"); ok {
+		t.Errorf("expected expired disk entry to be ignored")
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute, pokecache.WithMaxEntries(2))
+	defer cache.Close()
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	// a'ya erişince en son kullanılan o olur, b atılmalı
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("expected a to be kept")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected c to be kept")
+	}
+
+	sized := pokecache.NewCache(time.Minute, pokecache.WithMaxBytes(10))
+	defer sized.Close()
+	sized.Add("a", []byte("123456"))
+	sized.Add("b", []byte("123456"))
+	if _, ok := sized.Get("a"); ok {
+		t.Errorf("expected a to be evicted by size")
+	}
+	if sized.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", sized.Len())
+	}
+}
+
+func TestClose(t *testing.T) {
+	cache := pokecache.NewCache(time.Millisecond)
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.Close()
+	cache.Close()
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected closed cache to miss")
+	}
+	cache.Add("https://example.com", []byte("testdata"))
+	if cache.Len() != 0 {
+		t.Errorf("expected Add after Close to be ignored")
+	}
+}
+
+func TestClientListLocationAreas(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path != "/location-area/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"count":1,"next":null,"previous":null,"results":[{"name":"canalave-city-area","url":""}]}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache)
+
+	for i := 0; i < 2; i++ {
+		page, err := client.ListLocationAreas(context.Background(), server.URL+"/location-area/")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Results) != 1 || page.Results[0].Name != "canalave-city-area" {
+			t.Errorf("unexpected page: %+v", page)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected second call to be served from cache, got %d requests", hits)
+	}
+
+	_, err := client.ListLocationAreas(context.Background(), server.URL+"/missing/")
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClientBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.String() {
+		case "/api/v2/location-area/":
+			// Mirror'lar sayfalama linklerinde genelde pokeapi.co döner
+			fmt.Fprint(w, `{"next":"https://pokeapi.co/api/v2/location-area/?offset=20&limit=20","previous":null,"results":[{"name":"first"}]}`)
+		case "/api/v2/location-area/?offset=20&limit=20":
+			fmt.Fprint(w, `{"next":null,"previous":"https://pokeapi.co/api/v2/location-area/?offset=0&limit=20","results":[{"name":"second"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+
+	first, err := client.ListLocationAreas(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Next != server.URL+"/api/v2/location-area/?offset=20&limit=20" {
+		t.Errorf("expected next page on the mirror, got %s", first.Next)
+	}
+	second, err := client.ListLocationAreas(context.Background(), first.Next)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Results[0].Name != "second" {
+		t.Errorf("unexpected page: %+v", second)
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(configPath, []byte(`{"base_url":"http://from-file/api/v2/","cache_dir":"/tmp/from-file"}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("POKEDEX_PROFILE", "")
+	t.Setenv("POKEDEX_BASE_URL", "")
+	t.Setenv("POKEDEX_CACHE_DIR", "/tmp/from-env")
+	s, err := loadSettings([]string{"--config", configPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.BaseURL != "http://from-file/api/v2/" {
+		t.Errorf("expected base url from config file, got %s", s.BaseURL)
+	}
+	if s.CacheDir != "/tmp/from-env" {
+		t.Errorf("expected cache dir from env, got %s", s.CacheDir)
+	}
+
+	s, err = loadSettings([]string{"--config", configPath, "--base-url", "http://from-flag/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.BaseURL != "http://from-flag/" {
+		t.Errorf("expected base url from flag, got %s", s.BaseURL)
+	}
+}
+
+func TestClientOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("offline client should not hit the network: %s", r.URL)
+	}))
+	defer server.Close()
+
+	bundle := t.TempDir()
+	pikachuDir := filepath.Join(bundle, "pokemon", "pikachu")
+	if err := os.MkdirAll(pikachuDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err := os.WriteFile(filepath.Join(pikachuDir, "index.json"), []byte(`{"name":"pikachu","base_experience":112}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache,
+		pokeapi.WithBaseURL(server.URL),
+		pokeapi.WithOffline(true),
+		pokeapi.WithBundle(bundle),
+	)
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.BaseExperience != 112 {
+		t.Errorf("expected bundled pokemon, got %+v", pokemon.Name)
+	}
+
+	_, err = client.GetPokemon(context.Background(), "mew")
+	if !errors.Is(err, pokeapi.ErrOffline) {
+		t.Errorf("expected offline error, got %v", err)
+	}
+}
+
+func TestClientRevalidate(t *testing.T) {
+	bodies := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("If-None-Match") {
+		case `"v1"`:
+			// 304 yeni bir ETag taşıyabilir
+			w.Header().Set("ETag", `"v2"`)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case `"v2"`:
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		bodies++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	const interval = 5 * time.Millisecond
+	cache := pokecache.NewCache(interval)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL))
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reap döngüsü kaydı stale yapsın ama silmesin
+	time.Sleep(interval * 3)
+	if _, ok := cache.Get(server.URL + "/pokemon/pikachu/"); ok {
+		t.Errorf("expected entry to be stale")
+	}
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.BaseExperience != 112 {
+		t.Errorf("expected cached body after 304, got %+v", pokemon.Name)
+	}
+	if bodies != 1 {
+		t.Errorf("expected one body transfer, got %d", bodies)
+	}
+	if _, ok := cache.Get(server.URL + "/pokemon/pikachu/"); !ok {
+		t.Errorf("expected entry to be fresh again after 304")
+	}
+	_, validators, _ := cache.GetStale(server.URL + "/pokemon/pikachu/")
+	if validators.ETag != `"v2"` || validators.LastModified != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("expected the 304's ETag and the old Last-Modified, got %+v", validators)
+	}
+
+	// Sonraki doğrulama yeni ETag'le yapılmalı
+	time.Sleep(interval * 3)
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bodies != 1 {
+		t.Errorf("expected the new ETag to revalidate without a body, got %d transfers", bodies)
+	}
+}
+
+func TestClientCacheStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL))
+
+	// Önbellekte olmayan tek bir istek tek ıska sayılmalı
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats := cache.Stats(); stats.Misses != 1 || stats.Hits != 0 {
+		t.Errorf("expected 1 miss and 0 hits, got %d misses and %d hits", stats.Misses, stats.Hits)
+	}
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats := cache.Stats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("expected 1 miss and 1 hit, got %d misses and %d hits", stats.Misses, stats.Hits)
+	}
+}
+
+func TestClientCoalescesRequests(t *testing.T) {
+	const callers = 10
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetPokemon(context.Background(), "pikachu")
+			errs <- err
+		}()
+	}
+
+	// Herkes ilk isteği bekleyene kadar dur
+	key := server.URL + "/pokemon/pikachu/"
+	deadline := time.Now().Add(2 * time.Second)
+	for cache.Stats().InFlight[key] < callers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if waiters := cache.Stats().InFlight[key]; waiters != callers {
+		t.Errorf("expected %d callers in flight, got %d", callers, waiters)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected one request, got %d", hits.Load())
+	}
+	if len(cache.Stats().InFlight) != 0 {
+		t.Errorf("expected nothing in flight after the fetch")
+	}
+}
+
+func TestClientCoalescedCancel(t *testing.T) {
+	release := make(chan struct{})
+	aborted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "raichu") {
+			<-r.Context().Done()
+			aborted <- struct{}{}
+			return
+		}
+		<-release
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL))
+	waitInFlight := func(name string, n int) {
+		t.Helper()
+		key := server.URL + "/pokemon/" + name + "/"
+		deadline := time.Now().Add(2 * time.Second)
+		for cache.Stats().InFlight[key] != n && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if waiters := cache.Stats().InFlight[key]; waiters != n {
+			t.Fatalf("expected %d callers in flight, got %d", n, waiters)
+		}
+	}
+
+	// İlk çağıranın iptali bekleyen diğer çağıranı etkilememeli
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemon(leaderCtx, "pikachu")
+		leaderErr <- err
+	}()
+	waitInFlight("pikachu", 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemon(context.Background(), "pikachu")
+		waiterErr <- err
+	}()
+	waitInFlight("pikachu", 2)
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to be cancelled, got %v", err)
+	}
+	close(release)
+	if err := <-waiterErr; err != nil {
+		t.Errorf("expected the waiter to get the shared result, got %v", err)
+	}
+
+	// Son bekleyen de ayrılınca istek iptal edilmeli
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemon(ctx, "raichu")
+		errs <- err
+	}()
+	waitInFlight("raichu", 1)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the only caller to be cancelled, got %v", err)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(2 * time.Second):
+		t.Errorf("expected the request to be aborted once nobody waits")
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	cases := []struct {
+		name         string
+		failures     int
+		status       int
+		retryAfter   string
+		maxAttempts  int
+		expectedHits int32
+		expectErr    bool
+	}{
+		{name: "recovers from 5xx", failures: 2, status: http.StatusServiceUnavailable, maxAttempts: 3, expectedHits: 3},
+		{name: "honors Retry-After", failures: 1, status: http.StatusTooManyRequests, retryAfter: "0", maxAttempts: 3, expectedHits: 2},
+		{name: "gives up after max attempts", failures: 5, status: http.StatusInternalServerError, maxAttempts: 3, expectedHits: 3, expectErr: true},
+		{name: "does not retry 404", failures: 5, status: http.StatusNotFound, maxAttempts: 3, expectedHits: 1, expectErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(hits.Add(1)) <= c.failures {
+					if c.retryAfter != "" {
+						w.Header().Set("Retry-After", c.retryAfter)
+					}
+					w.WriteHeader(c.status)
+					return
+				}
+				fmt.Fprint(w, `{"name":"pikachu"}`)
+			}))
+			defer server.Close()
+
+			cache := pokecache.NewCache(time.Minute)
+			defer cache.Close()
+			client := pokeapi.NewClient(cache,
+				pokeapi.WithBaseURL(server.URL),
+				pokeapi.WithRetry(c.maxAttempts, time.Millisecond, 10*time.Millisecond),
+			)
+
+			_, err := client.GetPokemon(context.Background(), "pikachu")
+			if c.expectErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !c.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if hits.Load() != c.expectedHits {
+				t.Errorf("expected %d requests, got %d", c.expectedHits, hits.Load())
+			}
+		})
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"pokemon"}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	var throttled atomic.Int32
+	client := pokeapi.NewClient(cache,
+		pokeapi.WithBaseURL(server.URL),
+		pokeapi.WithRateLimit(50, 1),
+		pokeapi.WithThrottleNotice(func(wait time.Duration) {
+			throttled.Add(1)
+		}),
+	)
+
+	for _, name := range []string{"pikachu", "eevee", "mew"} {
+		if _, err := client.GetPokemon(context.Background(), name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if throttled.Load() != 2 {
+		t.Errorf("expected 2 throttled requests, got %d", throttled.Load())
+	}
+
+	// Cache'ten gelen cevaplar token harcamamalı
+	before := throttled.Load()
+	for i := 0; i < 5; i++ {
+		client.GetPokemon(context.Background(), "pikachu")
+	}
+	if throttled.Load() != before {
+		t.Errorf("expected cache hits not to be throttled")
+	}
+}
+
+func TestClientContextTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache, pokeapi.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected the request to stop at the deadline")
+	}
+}
+
+func TestClientAttemptTimeout(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			// İlk deneme takılıyor, istemci vazgeçene kadar bekle
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"name":"pikachu"}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := pokeapi.NewClient(cache,
+		pokeapi.WithBaseURL(server.URL),
+		pokeapi.WithRetry(3, time.Millisecond, 10*time.Millisecond),
+		pokeapi.WithAttemptTimeout(50*time.Millisecond),
+	)
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if pokemon.Name != "pikachu" || hits.Load() != 2 {
+		t.Errorf("expected pikachu after 2 requests, got %q after %d", pokemon.Name, hits.Load())
+	}
+}
+
+func TestRunCommandTimeoutMessage(t *testing.T) {
+	// Üç deneme gibi davranan komut, zaman aşımının kendisinden uzun sürer
+	slow := cliCommand{callback: func(ctx context.Context, cfg *config, args ...string) error {
+		time.Sleep(30 * time.Millisecond)
+		return fmt.Errorf("error getting pokemon: %w", context.DeadlineExceeded)
+	}}
+	err := runCommand(slow, 10*time.Millisecond, nil)
+	if err == nil || !strings.HasSuffix(err.Error(), "each attempt may take up to 10ms.") {
+		t.Fatalf("expected a per-attempt timeout message, got %v", err)
+	}
+	// "Timed out after <süre>, ..." komutun toplam süresini vermeli
+	elapsed, parseErr := time.ParseDuration(strings.TrimSuffix(strings.Fields(err.Error())[3], ","))
+	if parseErr != nil || elapsed < 30*time.Millisecond {
+		t.Errorf("expected the total elapsed time in %q", err)
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	f, err := savefile.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error for a missing save: %v", err)
+	}
+	f.Caught["pikachu"] = savefile.Caught{
+		Pokemon:  savefile.Pokemon{Name: "pikachu", BaseExperience: 112},
+		CaughtAt: time.Now(),
+		Location: "viridian-forest-area",
+	}
+	if err := savefile.Save(path, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := savefile.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caught, ok := loaded.Caught["pikachu"]
+	if !ok || caught.Pokemon.BaseExperience != 112 || caught.Location != "viridian-forest-area" {
+		t.Errorf("expected pikachu to survive a reload, got %+v", loaded.Caught)
+	}
+
+	// Biten toplar yeniden yüklenince başlangıç adedine dönmemeli
+	loaded.Bag = map[string]int{"poke-ball": 1}
+	if err := savefile.Save(path, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	depleted, err := savefile.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(depleted.Bag) != 1 || depleted.Bag["poke-ball"] != 1 {
+		t.Errorf("expected only one poke-ball after a reload, got %v", depleted.Bag)
+	}
+	depleted.Bag = map[string]int{}
+	if err := savefile.Save(path, depleted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	empty, err := savefile.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if empty.Bag == nil || len(empty.Bag) != 0 {
+		t.Errorf("expected an empty bag to stay empty, got %v", empty.Bag)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := savefile.Load(path)
+	if !errors.Is(err, savefile.ErrCorrupt) {
+		t.Errorf("expected corrupt save error, got %v", err)
+	}
+	if fresh == nil || len(fresh.Caught) != 0 {
+		t.Errorf("expected an empty save after corruption")
+	}
+	backups, _ := filepath.Glob(path + ".corrupt-*")
+	if len(backups) != 1 {
+		t.Errorf("expected the corrupt save to be backed up, found %v", backups)
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// Her migration adımı testdata/savefile/v<N>.json'ı alıp v<N+1>.json'ı üretmeli.
+// Bir adımın çıktısı bir sonrakinin girdisi olduğundan zincir boyunca eski kayıtlar yüklenebilir.
+func TestSaveMigrationsGolden(t *testing.T) {
+	for _, step := range savefile.Migrations() {
+		t.Run(fmt.Sprintf("v%d to v%d", step.From, step.From+1), func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join("testdata", "savefile", fmt.Sprintf("v%d.json", step.From)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var doc map[string]any
+			if err := json.Unmarshal(input, &doc); err != nil {
+				t.Fatal(err)
+			}
+			if err := step.Migrate(doc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			doc["version"] = step.From + 1
+			actual, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual = append(actual, '\n')
+
+			goldenPath := filepath.Join("testdata", "savefile", fmt.Sprintf("v%d.json", step.From+1))
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, actual, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(actual) != string(expected) {
+				t.Errorf("migration output does not match %s:\n%s", goldenPath, actual)
+			}
+		})
+	}
+}
+
+func TestSaveCheckDoesNotWrite(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "savefile", "v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, input, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := savefile.Check(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.FromVersion != 1 || report.ToVersion != savefile.CurrentVersion || report.Caught != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	after, _ := os.ReadFile(path)
+	if string(after) != string(input) {
+		t.Errorf("expected --check to leave the save untouched")
+	}
+
+	loaded, err := savefile.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pidgey := loaded.Caught["pidgey"].Pokemon
+	if pidgey.ID != 16 || len(pidgey.Stats) != 6 || pidgey.Types[1] != "flying" {
+		t.Errorf("unexpected migrated pokemon: %+v", pidgey)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); err != nil {
+		t.Errorf("expected a backup of the old save: %v", err)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("POKEDEX_PROFILE", "")
+	// Profil komutları global durumu değiştiriyor, sonraki testlere sızmasın
+	oldSave, oldPath, oldSettings, oldClient, oldCache, oldArgs, oldWild := saveData, savePath, activeSettings, client, cache, cliArgs, wild
+	t.Cleanup(func() {
+		if cache != oldCache {
+			cache.Close()
+		}
+		saveData, savePath, activeSettings, client, cache, cliArgs, wild = oldSave, oldPath, oldSettings, oldClient, oldCache, oldArgs, oldWild
+		resetMapLevels()
+	})
+
+	if err := ensureDefaultProfile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := loadSettings([]string{"--config", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Profile != defaultProfile || s.SaveFile != filepath.Join(profileDir(defaultProfile), "save.json") {
+		t.Errorf("expected the default profile, got %+v", s)
+	}
+	setup(s)
+	cache.Close()
+
+	ctx := context.Background()
+	if err := commandProfile(ctx, nil, "new", "Ash!"); err == nil {
+		t.Errorf("expected invalid profile name to be rejected")
+	}
+	if err := commandProfile(ctx, nil, "new", "misty"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cliArgs = []string{"--config", ""}
+	if err := commandProfile(ctx, nil, "use", "misty"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if readActiveProfile() != "misty" || savePath != filepath.Join(profileDir("misty"), "save.json") {
+		t.Errorf("expected misty to be active, got %s with save %s", readActiveProfile(), savePath)
+	}
+
+	// --save ve POKEDEX_SAVE_FILE sadece default profile uygulanır, isimli bir
+	// profil nasıl açılırsa açılsın kendi dosyalarını kullanır
+	shared := filepath.Join(t.TempDir(), "shared.json")
+	t.Setenv("POKEDEX_SAVE_FILE", shared)
+	def, err := loadSettings([]string{"--config", "", "--profile", defaultProfile})
+	if err != nil || def.SaveFile != shared {
+		t.Errorf("expected the default profile to use %s, got %s (%v)", shared, def.SaveFile, err)
+	}
+	mistySave, mistyHistory := filepath.Join(profileDir("misty"), "save.json"), filepath.Join(profileDir("misty"), "history.txt")
+	startup, err := loadSettings([]string{"--config", "", "--save", shared, "--profile", "misty"})
+	if err != nil || startup.SaveFile != mistySave || startup.HistoryFile != mistyHistory {
+		t.Errorf("expected misty to keep its own files at startup, got %+v (%v)", startup, err)
+	}
+	switched, err := loadSettingsFor([]string{"--config", "", "--save", shared}, "misty")
+	if err != nil || switched.SaveFile != mistySave || switched.HistoryFile != mistyHistory {
+		t.Errorf("expected misty to keep its own files after switching, got %+v (%v)", switched, err)
+	}
+	// Profilin kendi settings.json'ı dosyaları taşıyabilir
+	moved := filepath.Join(t.TempDir(), "misty.json")
+	if err := os.WriteFile(filepath.Join(profileDir("misty"), "settings.json"), []byte(fmt.Sprintf(`{"save_file": %q}`, moved)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	startup, _ = loadSettings([]string{"--config", "", "--profile", "misty"})
+	switched, _ = loadSettingsFor([]string{"--config", ""}, "misty")
+	if startup.SaveFile != moved || switched.SaveFile != moved {
+		t.Errorf("expected misty's settings.json to move its save, got %s and %s", startup.SaveFile, switched.SaveFile)
+	}
+	if err := os.Remove(filepath.Join(profileDir("misty"), "settings.json")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POKEDEX_SAVE_FILE", "")
+	// Okunamayan bir kayda geçiş misty'yi bozmamalı
+	if err := commandProfile(ctx, nil, "new", "brock"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	brockSave := filepath.Join(profileDir("brock"), "save.json")
+	future := []byte(`{"version": 99, "caught": {}}`)
+	if err := os.WriteFile(brockSave, future, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	saveData.Caught["staryu"] = savefile.Caught{Pokemon: savefile.Pokemon{Name: "staryu"}}
+	if err := commandProfile(ctx, nil, "use", "brock"); err == nil {
+		t.Errorf("expected switching to an unreadable save to fail")
+	}
+	if activeSettings.Profile != "misty" || readActiveProfile() != "misty" || savePath != filepath.Join(profileDir("misty"), "save.json") {
+		t.Errorf("expected misty to stay active, got %s with save %s", activeSettings.Profile, savePath)
+	}
+	if _, ok := saveData.Caught["staryu"]; !ok {
+		t.Errorf("expected misty's save to stay loaded")
+	}
+	if err := persist(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(brockSave); string(data) != string(future) {
+		t.Errorf("brock's save was overwritten: %s", data)
+	}
+	if err := commandProfile(ctx, nil, "delete", "brock"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := commandProfile(ctx, nil, "delete", "misty"); err == nil {
+		t.Errorf("expected deleting the active profile to fail")
+	}
+	if err := commandProfile(ctx, nil, "delete", "default"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	names, _ := listProfiles()
+	if len(names) != 1 || names[0] != "misty" {
+		t.Errorf("expected only misty to remain, got %v", names)
+	}
+}
+
+func TestCaptureProbability(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempt  capture.Attempt
+		expected float64
+	}{
+		{name: "common pokemon at full hp", attempt: capture.Attempt{CaptureRate: 255, HPFraction: 1, Ball: 1}, expected: 0.3332},
+		{name: "legendary at full hp", attempt: capture.Attempt{CaptureRate: 3, HPFraction: 1, Ball: 1}, expected: 0.0039},
+		{name: "starter at 1 hp asleep in an ultra ball", attempt: capture.Attempt{CaptureRate: 45, HPFraction: 0.01, Ball: 2, Status: capture.StatusSleep}, expected: 0.7011},
+		{name: "master ball", attempt: capture.Attempt{CaptureRate: 3, HPFraction: 1, Ball: 255}, expected: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := capture.Probability(c.attempt)
+			if math.Abs(actual-c.expected) > 0.001 {
+				t.Errorf("expected %.4f, got %.4f", c.expected, actual)
+			}
+		})
+	}
+
+	// Çok sayıda atışta oran hesaplanan olasılığa yakın olmalı
+	attempt := capture.Attempt{CaptureRate: 45, HPFraction: 1, Ball: 1}
+	r := rand.New(rand.NewSource(1))
+	caught := 0
+	const throws = 20000
+	for i := 0; i < throws; i++ {
+		if ok, _ := capture.Throw(attempt, r); ok {
+			caught++
+		}
+	}
+	if math.Abs(float64(caught)/throws-capture.Probability(attempt)) > 0.01 {
+		t.Errorf("expected about %.3f caught, got %.3f", capture.Probability(attempt), float64(caught)/throws)
+	}
+}
+
+func TestCatchWithBalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/item/poke-ball/", "/api/v2/item/great-ball/", "/api/v2/item/ultra-ball/":
+			name := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[3]
+			fmt.Fprintf(w, `{"name":%q,"category":{"name":"standard-balls"}}`, name)
+		case "/api/v2/item/potion/":
+			fmt.Fprint(w, `{"name":"potion","category":{"name":"healing"},"names":[{"name":"Potion","language":{"name":"en"}}]}`)
+		case "/api/v2/pokemon/rattata/":
+			fmt.Fprint(w, `{"id":19,"name":"rattata","species":{"name":"rattata"},"types":[{"type":{"name":"normal"}}]}`)
+		case "/api/v2/pokemon-species/rattata/":
+			fmt.Fprint(w, `{"id":19,"name":"rattata","capture_rate":255}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testCache := pokecache.NewCache(time.Minute)
+	defer testCache.Close()
+	oldClient, oldSave, oldPath, oldWild, oldRng := client, saveData, savePath, wild, rng
+	defer func() { client, saveData, savePath, wild, rng = oldClient, oldSave, oldPath, oldWild, oldRng }()
+	client = pokeapi.NewClient(testCache, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	savePath = filepath.Join(t.TempDir(), "save.json")
+	rng = rand.New(rand.NewSource(1))
+	ctx := context.Background()
+
+	newWild := func() {
+		wild = &wildEncounter{Pokemon: "rattata", Level: 3, Area: "route-1", HPFraction: 1}
+		saveData = savefile.New()
+	}
+
+	newWild()
+	_, pokeModifier, err := chooseBall(ctx, "poke-ball")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, ultraModifier, err := chooseBall(ctx, "ultra-ball")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attempt := capture.Attempt{CaptureRate: 45, HPFraction: 1, Ball: pokeModifier}
+	withUltra := attempt
+	withUltra.Ball = ultraModifier
+	if capture.Probability(withUltra) <= capture.Probability(attempt) {
+		t.Errorf("expected an ultra ball to raise the odds: %v vs %v", capture.Probability(withUltra), capture.Probability(attempt))
+	}
+
+	newWild()
+	saveData.Bag = map[string]int{"poke-ball": 1}
+	if err := commandCatch(ctx, nil, "rattata", "great-ball"); err == nil {
+		t.Errorf("expected a throw without great balls to be refused")
+	}
+	if saveData.Bag["poke-ball"] != 1 || wild == nil {
+		t.Errorf("a refused throw should not change the bag or the encounter: %v", saveData.Bag)
+	}
+
+	newWild()
+	saveData.Bag = map[string]int{"potion": 1}
+	if err := commandCatch(ctx, nil, "potion"); err == nil || !strings.Contains(err.Error(), "not a Poke Ball") {
+		t.Errorf("expected a potion to be rejected, got %v", err)
+	}
+	if saveData.Bag["potion"] != 1 {
+		t.Errorf("a rejected item should stay in the bag: %v", saveData.Bag)
+	}
+
+	newWild()
+	saveData.Bag = map[string]int{"poke-ball": 2}
+	if err := commandCatch(ctx, nil, "rattata"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saveData.Bag["poke-ball"] != 1 {
+		t.Errorf("expected one poke-ball left, got %v", saveData.Bag)
+	}
+	newWild()
+	saveData.Bag = map[string]int{"poke-ball": 1}
+	if err := commandCatch(ctx, nil, "rattata", "poke-ball"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := saveData.Bag["poke-ball"]; ok {
+		t.Errorf("expected the empty poke-ball slot to be removed, got %v", saveData.Bag)
+	}
+	if err := commandBag(ctx, nil); err == nil {
+		t.Errorf("expected an empty bag to be reported")
+	}
+}
+
+func TestRollEncounter(t *testing.T) {
+	var area pokeapi.LocationArea
+	err := json.Unmarshal([]byte(`{
+		"name": "viridian-forest-area",
+		"pokemon_encounters": [
+			{"pokemon": {"name": "caterpie"}, "version_details": [
+				{"version": {"name": "red"}, "encounter_details": [{"chance": 50, "min_level": 3, "max_level": 5, "method": {"name": "walk"}}]}
+			]},
+			{"pokemon": {"name": "pikachu"}, "version_details": [
+				{"version": {"name": "red"}, "encounter_details": [{"chance": 5, "min_level": 3, "max_level": 3, "method": {"name": "walk"}}]},
+				{"version": {"name": "yellow"}, "encounter_details": [{"chance": 100, "min_level": 4, "max_level": 4, "method": {"name": "walk"}}]}
+			]},
+			{"pokemon": {"name": "magikarp"}, "version_details": [
+				{"version": {"name": "red"}, "encounter_details": [{"chance": 100, "min_level": 5, "max_level": 5, "method": {"name": "old-rod"}}]}
+			]}
+		]
+	}`), &area)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		enc, err := rollEncounter(area, "red", "", r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if enc.Method != "walk" {
+			t.Errorf("expected walking encounters by default, got %s", enc.Method)
+		}
+		if enc.Pokemon == "caterpie" && (enc.Level < 3 || enc.Level > 5) {
+			t.Errorf("level %d out of range", enc.Level)
+		}
+		seen[enc.Pokemon]++
+	}
+	if seen["magikarp"] != 0 || seen["caterpie"] < seen["pikachu"]*3 {
+		t.Errorf("unexpected encounter distribution: %v", seen)
+	}
+
+	enc, err := rollEncounter(area, "yellow", "", r)
+	if err != nil || enc.Pokemon != "pikachu" || enc.Level != 4 {
+		t.Errorf("expected a level 4 pikachu in yellow, got %+v (%v)", enc, err)
+	}
+	enc, err = rollEncounter(area, "red", "old-rod", r)
+	if err != nil || enc.Pokemon != "magikarp" {
+		t.Errorf("expected magikarp with the old rod, got %+v (%v)", enc, err)
+	}
+	if _, err := rollEncounter(area, "gold", "", r); err == nil {
+		t.Errorf("expected an error for a version without encounters")
+	}
+}
+
+func TestMapLevels(t *testing.T) {
+	var locations []string
+	for i := 1; i <= 25; i++ {
+		locations = append(locations, fmt.Sprintf(`{"name":"location-%d"}`, i))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/region/kanto/":
+			fmt.Fprintf(w, `{"name":"kanto","locations":[%s]}`, strings.Join(locations, ","))
+		case "/api/v2/location/viridian-forest/":
+			fmt.Fprint(w, `{"name":"viridian-forest","region":{"name":"kanto"},"areas":[{"name":"viridian-forest-area"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testCache := pokecache.NewCache(time.Minute)
+	defer testCache.Close()
+	oldClient := client
+	defer func() { client = oldClient }()
+	client = pokeapi.NewClient(testCache, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	resetMapLevels()
+	defer resetMapLevels()
+
+	ctx := context.Background()
+	flat := &config{Next: "unused"}
+	if err := commandMap(ctx, flat, "kanto"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kanto := mapLevels["kanto"]
+	if mapLevel != "kanto" || kanto == nil || kanto.Next != "20" || kanto.Previous != "" {
+		t.Fatalf("unexpected kanto cursor after the first page: %q %+v", mapLevel, kanto)
+	}
+	// Argümansız map aynı seviyede devam eder
+	if err := commandMap(ctx, flat); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kanto.Next != "" || kanto.Previous != "0" {
+		t.Errorf("unexpected kanto cursor after the last page: %+v", kanto)
+	}
+
+	if err := commandMap(ctx, flat, "kanto/viridian-forest"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapLevel != "kanto/viridian-forest" || mapLevels["kanto"] != kanto {
+		t.Errorf("expected a separate cursor per level, got %q %v", mapLevel, mapLevels)
+	}
+	if err := commandMap(ctx, flat, "johto/viridian-forest"); err == nil {
+		t.Errorf("expected an error for a location in another region")
+	}
+	if mapLevel != "kanto/viridian-forest" {
+		t.Errorf("a failed map should not change the level, got %q", mapLevel)
+	}
+	if flat.Next != "unused" {
+		t.Errorf("drill-down pages should not move the flat cursor")
+	}
+}
+
+func TestTravel(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v2/location-area/viridian-forest-area/":
+			fmt.Fprint(w, `{"name":"viridian-forest-area","location":{"name":"viridian-forest"},"pokemon_encounters":[{"pokemon":{"name":"caterpie"},"version_details":[{"version":{"name":"red"}}]}]}`)
+		case "/api/v2/location/viridian-forest/":
+			fmt.Fprint(w, `{"name":"viridian-forest","region":{"name":"kanto"},"areas":[{"name":"viridian-forest-area"},{"name":"viridian-forest-clearing"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testCache := pokecache.NewCache(time.Minute)
+	defer testCache.Close()
+	oldClient, oldSave, oldPath, oldWild, oldVersion := client, saveData, savePath, wild, activeSettings.GameVersion
+	defer func() {
+		client, saveData, savePath, wild, activeSettings.GameVersion = oldClient, oldSave, oldPath, oldWild, oldVersion
+	}()
+	client = pokeapi.NewClient(testCache, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	saveData = savefile.New()
+	savePath = filepath.Join(t.TempDir(), "save.json")
+	wild = nil
+	activeSettings.GameVersion = ""
+
+	// Komutların çıktısını yakalamak için stdout'u geçici olarak değiştiriyoruz
+	run := func(cmd func(context.Context, *config, ...string) error, args ...string) (string, error) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout := os.Stdout
+		os.Stdout = w
+		cmdErr := cmd(context.Background(), &config{}, args...)
+		os.Stdout = stdout
+		w.Close()
+		out, _ := io.ReadAll(r)
+		r.Close()
+		return string(out), cmdErr
+	}
+
+	if _, err := run(commandWhere); err == nil {
+		t.Errorf("expected where to fail before traveling")
+	}
+	if _, err := run(commandExplore); err == nil {
+		t.Errorf("expected explore without an area to fail before traveling")
+	}
+
+	out, err := run(commandGoto, "viridian-forest-area")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "You traveled to viridian-forest-area.") {
+		t.Errorf("unexpected goto output: %q", out)
+	}
+	if saveData.Location != "viridian-forest-area" {
+		t.Errorf("expected the location to be set, got %q", saveData.Location)
+	}
+	loaded, err := savefile.Load(savePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Location != "viridian-forest-area" {
+		t.Errorf("expected the location to be saved, got %q", loaded.Location)
+	}
+
+	// Argümansız explore bulunulan alanı keşfeder
+	out, err = run(commandExplore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Exploring viridian-forest-area...") || !strings.Contains(out, "- caterpie") {
+		t.Errorf("unexpected explore output: %q", out)
+	}
+
+	out, err = run(commandWhere)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Area: viridian-forest-area", "Location: viridian-forest", "Region: kanto", "Nearby areas:\n  - viridian-forest-clearing\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected where output to contain %q, got %q", want, out)
+		}
+	}
+	if strings.Contains(out, "  - viridian-forest-area") {
+		t.Errorf("the current area shouldn't be listed as nearby: %q", out)
+	}
+
+	// Bilinmeyen bir alana gidilemez, konum ve kayıt değişmez
+	requested = nil
+	if _, err := run(commandGoto, "cerulean-cave-9f"); err == nil {
+		t.Errorf("expected goto to an unknown area to fail")
+	}
+	if len(requested) != 1 || requested[0] != "/api/v2/location-area/cerulean-cave-9f/" {
+		t.Errorf("expected goto to look the area up, got %v", requested)
+	}
+	if saveData.Location != "viridian-forest-area" {
+		t.Errorf("expected the location to stay, got %q", saveData.Location)
+	}
+	if loaded, err := savefile.Load(savePath); err != nil || loaded.Location != "viridian-forest-area" {
+		t.Errorf("expected the saved location to stay, got %+v (%v)", loaded, err)
+	}
+}
+
+func TestVersionFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/version/red/":
+			fmt.Fprint(w, `{"name":"red","version_group":{"name":"red-blue"}}`)
+		case "/api/v2/version-group/scarlet-violet/":
+			fmt.Fprint(w, `{"name":"scarlet-violet","versions":[{"name":"scarlet"},{"name":"violet"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testCache := pokecache.NewCache(time.Minute)
+	defer testCache.Close()
+	oldClient := client
+	defer func() { client = oldClient }()
+	client = pokeapi.NewClient(testCache, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+
+	ctx := context.Background()
+	red, err := resolveVersion(ctx, "red")
+	if err != nil || red.Group != "red-blue" || !red.has("red") {
+		t.Fatalf("unexpected red version: %+v (%v)", red, err)
+	}
+	sv, err := resolveVersion(ctx, "scarlet-violet")
+	if err != nil || sv.Group != "scarlet-violet" || !sv.has("violet") {
+		t.Fatalf("unexpected scarlet-violet group: %+v (%v)", sv, err)
+	}
+	if _, err := resolveVersion(ctx, "pokemon-purple"); err == nil {
+		t.Errorf("expected an error for an unknown version")
+	}
+
+	var area pokeapi.LocationArea
+	err = json.Unmarshal([]byte(`{
+		"name": "viridian-forest-area",
+		"pokemon_encounters": [
+			{"pokemon": {"name": "caterpie"}, "version_details": [{"version": {"name": "red"}, "encounter_details": [{"chance": 50, "method": {"name": "walk"}}]}]},
+			{"pokemon": {"name": "pikachu"}, "version_details": [{"version": {"name": "yellow"}, "encounter_details": [{"chance": 5, "method": {"name": "walk"}}]}]}
+		]
+	}`), &area)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := areaPokemon(area, red); len(names) != 1 || names[0] != "caterpie" {
+		t.Errorf("expected only caterpie in red, got %v", names)
+	}
+	if version, err := pickEncounterVersion(area, red); err != nil || version != "red" {
+		t.Errorf("expected red encounters, got %q (%v)", version, err)
+	}
+	if _, err := pickEncounterVersion(area, sv); err == nil {
+		t.Errorf("expected an error for an area missing from scarlet-violet")
+	}
+
+	var pokemon pokeapi.Pokemon
+	err = json.Unmarshal([]byte(`{
+		"name": "pikachu",
+		"game_indices": [{"version": {"name": "red"}}],
+		"moves": [
+			{"move": {"name": "thunder-shock"}, "version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "scarlet-violet"}}
+			]},
+			{"move": {"name": "thunderbolt"}, "version_group_details": [
+				{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}
+			]}
+		]
+	}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pokemonInVersion(pokemon, red) || !pokemonInVersion(pokemon, sv) {
+		t.Errorf("expected pikachu in both red and scarlet-violet")
+	}
+	if moves := learnset(pokemon, "red-blue"); len(moves) != 2 || moves[0].Method != "level-up" {
+		t.Errorf("unexpected red-blue learnset: %+v", moves)
+	}
+	if moves := learnset(pokemon, "scarlet-violet"); len(moves) != 1 {
+		t.Errorf("unexpected scarlet-violet learnset: %+v", moves)
+	}
+}
+
+func TestPokemonInfo(t *testing.T) {
+	var pokemon pokeapi.Pokemon
+	var species pokeapi.PokemonSpecies
+	err := json.Unmarshal([]byte(`{
+		"id": 25, "name": "pikachu", "height": 4, "weight": 60,
+		"abilities": [{"ability": {"name": "static"}}, {"ability": {"name": "lightning-rod"}, "is_hidden": true}],
+		"stats": [{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 90, "stat": {"name": "speed"}}],
+		"types": [{"type": {"name": "electric"}}]
+	}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal([]byte(`{
+		"id": 25, "name": "pikachu", "gender_rate": 4,
+		"pokedex_numbers": [{"entry_number": 25, "pokedex": {"name": "national"}}, {"entry_number": 104, "pokedex": {"name": "original-johto"}}],
+		"genera": [{"genus": "Maus-Pokémon", "language": {"name": "de"}}, {"genus": "Mouse Pokémon", "language": {"name": "en"}}],
+		"egg_groups": [{"name": "ground"}, {"name": "fairy"}],
+		"flavor_text_entries": [
+			{"flavor_text": "When several of\nthese POKéMON", "language": {"name": "en"}, "version": {"name": "red"}},
+			{"flavor_text": "It keeps its tail\fraised", "language": {"name": "en"}, "version": {"name": "yellow"}}
+		]
+	}`), &species)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := newPokemonInfo(pokemon, species, []string{"red"})
+	if info.DexNumber != 25 || info.Genus != "Mouse Pokémon" {
+		t.Errorf("unexpected dex number or genus: %+v", info)
+	}
+	if info.FlavorText != "When several of these POKéMON" {
+		t.Errorf("expected the red flavor text, got %q", info.FlavorText)
+	}
+	if got := newPokemonInfo(pokemon, species, nil).FlavorText; got != "It keeps its tail raised" {
+		t.Errorf("expected the latest flavor text, got %q", got)
+	}
+	if len(info.Abilities) != 2 || !info.Abilities[1].Hidden {
+		t.Errorf("expected lightning-rod as a hidden ability: %+v", info.Abilities)
+	}
+	if len(info.EggGroups) != 2 || info.Pokemon.Types[0] != "electric" {
+		t.Errorf("unexpected egg groups or types: %+v", info)
+	}
+
+	cases := map[int]string{
+		-1: "genderless",
+		0:  "100.0% male, 0.0% female",
+		1:  "87.5% male, 12.5% female",
+		8:  "0.0% male, 100.0% female",
+	}
+	for rate, want := range cases {
+		if got := genderRatio(rate); got != want {
+			t.Errorf("genderRatio(%d) = %q, want %q", rate, got, want)
+		}
+	}
+}
+
+func TestParseInspectArgs(t *testing.T) {
+	name, sections, err := parseInspectArgs([]string{"Pikachu", "--moves", "--items"})
+	if err != nil || name != "pikachu" {
+		t.Fatalf("unexpected result: %q (%v)", name, err)
+	}
+	if !sections.Moves || !sections.Items || sections.Abilities || !sections.any() {
+		t.Errorf("unexpected sections: %+v", sections)
+	}
+	if _, sections, _ := parseInspectArgs([]string{"pikachu"}); sections.any() {
+		t.Errorf("expected only the summary without flags")
+	}
+	if _, sections, _ := parseInspectArgs([]string{"--all", "pikachu"}); !sections.Games || !sections.Cries {
+		t.Errorf("expected --all to enable every section: %+v", sections)
+	}
+	if _, _, err := parseInspectArgs([]string{"pikachu", "--evs"}); err == nil {
+		t.Errorf("expected an error for an unknown flag")
+	}
+	if _, _, err := parseInspectArgs([]string{"pikachu", "raichu"}); err == nil {
+		t.Errorf("expected an error for two pokemon names")
+	}
+
+	var pokemon pokeapi.Pokemon
+	err = json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "tackle"}, "version_group_details": [{"version_group": {"name": "red-blue"}}, {"version_group": {"name": "x-y"}}]},
+		{"move": {"name": "growl"}, "version_group_details": [{"version_group": {"name": "x-y"}}]}
+	]}`), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group := defaultMoveGroup(pokemon); group != "x-y" {
+		t.Errorf("expected x-y as the default move group, got %q", group)
+	}
+}
+
+func TestEvolution(t *testing.T) {
+	var chain pokeapi.EvolutionChain
+	err := json.Unmarshal([]byte(`{"chain": {
+		"species": {"name": "eevee"},
+		"evolves_to": [
+			{"species": {"name": "vaporeon"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}], "evolves_to": []},
+			{"species": {"name": "umbreon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}], "evolves_to": []},
+			{"species": {"name": "leafeon"}, "evolution_details": [
+				{"trigger": {"name": "level-up"}, "location": {"name": "eterna-forest"}},
+				{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}
+			], "evolves_to": []}
+		]
+	}}`), &chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "eevee\n" +
+		"├─ vaporeon (use water-stone)\n" +
+		"├─ umbreon (level up with high friendship at night)\n" +
+		"└─ leafeon (level up at eterna-forest or use leaf-stone)\n"
+	if got := renderEvolutionTree(chain.Chain); got != want {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	link, ok := findChainLink(chain.Chain, "eevee")
+	if !ok {
+		t.Fatal("expected to find eevee in the chain")
+	}
+	caught := savefile.Caught{Level: 30}
+	options := evolutionOptions(link, caught, map[string]int{"leaf-stone": 1}, time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC))
+	if len(options) != 3 {
+		t.Fatalf("expected 3 options, got %+v", options)
+	}
+	if options[0].Reason == "" || options[1].Reason == "" {
+		t.Errorf("expected vaporeon and umbreon to be blocked: %+v", options)
+	}
+	if options[2].Reason != "" || options[2].Item != "leaf-stone" {
+		t.Errorf("expected leafeon with the leaf stone: %+v", options[2])
+	}
+
+	level := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: "level-up"}, MinLevel: 16}
+	if _, reason := checkEvolution(level, savefile.Caught{Level: 15}, nil, time.Now()); reason == "" {
+		t.Errorf("expected level 15 to be too low")
+	}
+	if _, reason := checkEvolution(level, savefile.Caught{Level: 16}, nil, time.Now()); reason != "" {
+		t.Errorf("expected level 16 to be enough, got %q", reason)
+	}
+	trade := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: "trade"}}
+	if _, reason := checkEvolution(trade, savefile.Caught{Level: 100}, nil, time.Now()); reason == "" {
+		t.Errorf("expected trades to be impossible")
+	}
+}
+
+// 6. nesil ve sonrası tür tablosu, sadece 1x olmayan saldırı çarpanları
+var knownTypeChart = map[string][3][]string{
+	"normal":   {nil, {"rock", "steel"}, {"ghost"}},
+	"fire":     {{"grass", "ice", "bug", "steel"}, {"fire", "water", "rock", "dragon"}, nil},
+	"water":    {{"fire", "ground", "rock"}, {"water", "grass", "dragon"}, nil},
+	"electric": {{"water", "flying"}, {"electric", "grass", "dragon"}, {"ground"}},
+	"grass":    {{"water", "ground", "rock"}, {"fire", "grass", "poison", "flying", "bug", "dragon", "steel"}, nil},
+	"ice":      {{"grass", "ground", "flying", "dragon"}, {"fire", "water", "ice", "steel"}, nil},
+	"fighting": {{"normal", "ice", "rock", "dark", "steel"}, {"poison", "flying", "psychic", "bug", "fairy"}, {"ghost"}},
+	"poison":   {{"grass", "fairy"}, {"poison", "ground", "rock", "ghost"}, {"steel"}},
+	"ground":   {{"fire", "electric", "poison", "rock", "steel"}, {"grass", "bug"}, {"flying"}},
+	"flying":   {{"grass", "fighting", "bug"}, {"electric", "rock", "steel"}, nil},
+	"psychic":  {{"fighting", "poison"}, {"psychic", "steel"}, {"dark"}},
+	"bug":      {{"grass", "psychic", "dark"}, {"fire", "fighting", "poison", "flying", "ghost", "steel", "fairy"}, nil},
+	"rock":     {{"fire", "ice", "flying", "bug"}, {"fighting", "ground", "steel"}, nil},
+	"ghost":    {{"psychic", "ghost"}, {"dark"}, {"normal"}},
+	"dragon":   {{"dragon"}, {"steel"}, {"fairy"}},
+	"dark":     {{"psychic", "ghost"}, {"fighting", "dark", "fairy"}, nil},
+	"steel":    {{"ice", "rock", "fairy"}, {"fire", "water", "electric", "steel"}, nil},
+	"fairy":    {{"fighting", "dragon", "dark"}, {"fire", "poison", "steel"}, nil},
+}
+
+// knownCharts tabloyu bir kez sadece saldırı (to), bir kez sadece savunma (from)
+// ilişkileriyle kurar, iki yol da aynı sonucu vermeli
+func knownCharts() map[string]*typechart.Chart {
+	offensive, defensive := typechart.New(), typechart.New()
+	from := make(map[string]*typechart.DamageRelations)
+	for name := range knownTypeChart {
+		from[name] = &typechart.DamageRelations{}
+	}
+	for name, rel := range knownTypeChart {
+		offensive.Add(name, typechart.DamageRelations{DoubleDamageTo: rel[0], HalfDamageTo: rel[1], NoDamageTo: rel[2]})
+		for _, d := range rel[0] {
+			from[d].DoubleDamageFrom = append(from[d].DoubleDamageFrom, name)
+		}
+		for _, d := range rel[1] {
+			from[d].HalfDamageFrom = append(from[d].HalfDamageFrom, name)
+		}
+		for _, d := range rel[2] {
+			from[d].NoDamageFrom = append(from[d].NoDamageFrom, name)
+		}
+	}
+	for name, rel := range from {
+		defensive.Add(name, *rel)
+	}
+	return map[string]*typechart.Chart{"to": offensive, "from": defensive}
+}
+
+func TestTypeEffectiveness(t *testing.T) {
+	cases := []struct {
+		attacking string
+		defending []string
+		want      float64
+	}{
+		{"water", []string{"fire"}, 2},
+		{"normal", []string{"ghost"}, 0},
+		{"ground", []string{"flying"}, 0},
+		{"dragon", []string{"fairy"}, 0},
+		{"fire", []string{"fire"}, 0.5},
+		{"psychic", []string{"normal"}, 1},
+		{"ice", []string{"dragon", "flying"}, 4},
+		{"electric", []string{"water", "flying"}, 4},
+		{"grass", []string{"water", "ground"}, 4},
+		{"fire", []string{"water", "rock"}, 0.25},
+		{"fighting", []string{"normal", "ghost"}, 0},
+		{"rock", []string{"fire", "flying"}, 4},
+		{"bug", []string{"fire", "flying"}, 0.25},
+		{"fighting", []string{"steel", "rock"}, 4},
+		{"fire", []string{"grass", "water"}, 1},
+	}
+	for source, chart := range knownCharts() {
+		for _, c := range cases {
+			if got := chart.Effectiveness(c.attacking, c.defending...); got != c.want {
+				t.Errorf("[%s] %s vs %v = %v, want %v", source, c.attacking, c.defending, got, c.want)
+			}
+		}
+	}
+}
+
+func TestTypeCoverage(t *testing.T) {
+	cases := []struct {
+		name  string
+		types []string
+		want  map[string]float64
+		build func(*typechart.Chart, ...string) map[string]float64
+	}{
+		{
+			name:  "fire/flying defending",
+			types: []string{"fire", "flying"},
+			want: map[string]float64{
+				"rock": 4, "water": 2, "electric": 2, "ground": 0,
+				"grass": 0.25, "bug": 0.25, "fire": 0.5, "fighting": 0.5, "steel": 0.5, "fairy": 0.5,
+			},
+			build: (*typechart.Chart).Defensive,
+		},
+		{
+			name:  "normal/ghost defending",
+			types: []string{"normal", "ghost"},
+			want:  map[string]float64{"normal": 0, "fighting": 0, "ghost": 0, "dark": 2, "bug": 0.5, "poison": 0.5},
+			build: (*typechart.Chart).Defensive,
+		},
+		{
+			name:  "steel defending",
+			types: []string{"steel"},
+			want: map[string]float64{
+				"fire": 2, "fighting": 2, "ground": 2, "poison": 0,
+				"normal": 0.5, "grass": 0.5, "ice": 0.5, "flying": 0.5, "psychic": 0.5, "bug": 0.5,
+				"rock": 0.5, "dragon": 0.5, "steel": 0.5, "fairy": 0.5,
+			},
+			build: (*typechart.Chart).Defensive,
+		},
+		{
+			name:  "fire/flying attacking",
+			types: []string{"fire", "flying"},
+			want:  map[string]float64{"grass": 2, "ice": 2, "bug": 2, "steel": 2, "fighting": 2, "rock": 0.5},
+			build: (*typechart.Chart).Offensive,
+		},
+	}
+	for source, chart := range knownCharts() {
+		for _, c := range cases {
+			got := c.build(chart, c.types...)
+			if len(got) != len(c.want) {
+				t.Errorf("[%s] %s: got %v, want %v", source, c.name, got, c.want)
+				continue
+			}
+			for typ, m := range c.want {
+				if got[typ] != m {
+					t.Errorf("[%s] %s: %s = %v, want %v", source, c.name, typ, got[typ], m)
+				}
+			}
+		}
+	}
+
+	keys, groups := typechart.Group(map[string]float64{"rock": 4, "water": 2, "electric": 2, "ground": 0})
+	if len(keys) != 3 || keys[0] != 4 || keys[2] != 0 || strings.Join(groups[2], ",") != "electric,water" {
+		t.Errorf("unexpected groups: %v %v", keys, groups)
+	}
+}
+
+func TestBattleStats(t *testing.T) {
+	// Bulbapedia'daki Lv. 78 Garchomp örneği, nature etkisi olmadan
+	garchomp := battle.Calculate(
+		battle.Stats{108, 130, 95, 80, 85, 102},
+		battle.Stats{24, 12, 30, 16, 23, 5},
+		battle.Stats{74, 190, 91, 48, 84, 23},
+		78,
+	)
+	if want := (battle.Stats{289, 253, 193, 151, 171, 171}); garchomp != want {
+		t.Errorf("got %v, want %v", garchomp, want)
+	}
+
+	cases := []struct {
+		evs, yield, want battle.Stats
+	}{
+		{battle.Stats{}, battle.Stats{0, 0, 0, 0, 0, 1}, battle.Stats{0, 0, 0, 0, 0, 1}},
+		{battle.Stats{0, 251}, battle.Stats{0, 2}, battle.Stats{0, 252}},
+		{battle.Stats{252, 252, 5}, battle.Stats{0, 0, 3, 0, 0, 2}, battle.Stats{252, 252, 6}},
+	}
+	for _, c := range cases {
+		if got := battle.GainEVs(c.evs, c.yield); got != c.want {
+			t.Errorf("GainEVs(%v, %v) = %v, want %v", c.evs, c.yield, got, c.want)
+		}
+	}
+
+	ivs := statsFromMap(map[string]int{"hp": 31, "speed": 7, "unknown": 3})
+	if ivs != (battle.Stats{31, 0, 0, 0, 0, 7}) || statsMap(ivs)["speed"] != 7 {
+		t.Errorf("unexpected stat map conversion: %v", ivs)
+	}
+}
+
+func TestBattleDamage(t *testing.T) {
+	attacker := &battle.Combatant{Level: 50, Types: []string{"normal"}, Stats: battle.Stats{100, 100, 100, 200, 100, 100}}
+	defender := &battle.Combatant{Level: 50, Stats: battle.Stats{100, 100, 100, 100, 100, 100}}
+	weak := &battle.Combatant{Level: 5, Stats: battle.Stats{20, 10, 10, 10, 10, 10}}
+	wall := &battle.Combatant{Level: 5, Stats: battle.Stats{20, 50, 50, 50, 50, 50}}
+	body := battle.Move{Type: "normal", Class: "physical", Power: 80}
+
+	cases := []struct {
+		name          string
+		attacker      *battle.Combatant
+		defender      *battle.Combatant
+		move          battle.Move
+		effectiveness float64
+		critical      bool
+		random        int
+		want          int
+	}{
+		{"stab super effective", attacker, defender, body, 2, false, 100, 110},
+		{"lowest roll", attacker, defender, body, 2, false, 85, 92},
+		{"critical without stab", attacker, defender, battle.Move{Type: "fire", Class: "physical", Power: 80}, 1, true, 100, 55},
+		{"special uses special stats", attacker, defender, battle.Move{Type: "psychic", Class: "special", Power: 90}, 1, false, 100, 81},
+		{"at least one damage", weak, wall, battle.Move{Type: "grass", Class: "physical", Power: 40}, 0.25, false, 85, 1},
+		{"immune", attacker, defender, body, 0, false, 100, 0},
+	}
+	for _, c := range cases {
+		if got := battle.Damage(c.attacker, c.defender, c.move, c.effectiveness, c.critical, c.random); got != c.want {
+			t.Errorf("%s: got %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestBattleTurns(t *testing.T) {
+	chart := knownCharts()["to"]
+	thunderShock := battle.Move{Name: "thunder-shock", Type: "electric", Class: "special", Power: 40, Accuracy: 100}
+	quickAttack := battle.Move{Name: "quick-attack", Type: "normal", Class: "physical", Power: 40, Accuracy: 100, Priority: 1}
+	tackle := battle.Move{Name: "tackle", Type: "normal", Class: "physical", Power: 40, Accuracy: 100}
+	newBattle := func(seed int64, opponentTypes []string, opponentMoves ...battle.Move) *battle.Battle {
+		ivs := battle.Stats{31, 31, 31, 31, 31, 31}
+		player := battle.NewCombatant("pikachu", 20, []string{"electric"}, battle.Stats{35, 55, 40, 50, 50, 90}, ivs, battle.Stats{}, []battle.Move{thunderShock})
+		opponent := battle.NewCombatant("wild", 18, opponentTypes, battle.Stats{40, 45, 40, 35, 35, 56}, ivs, battle.Stats{}, opponentMoves)
+		return battle.New(player, opponent, chart, rand.New(rand.NewSource(seed)))
+	}
+
+	b := newBattle(1, []string{"normal", "flying"}, tackle)
+	events := b.PlayTurn(0, 0)
+	if events[0].Attacker != "pikachu" || events[0].Effectiveness != 2 {
+		t.Errorf("expected the faster pikachu to hit first for 2x: %+v", events[0])
+	}
+	b = newBattle(1, []string{"normal", "flying"}, quickAttack)
+	if events := b.PlayTurn(0, 0); events[0].Attacker != "wild" {
+		t.Errorf("expected quick-attack to go first: %+v", events)
+	}
+	b = newBattle(1, []string{"ground"})
+	if events := b.PlayTurn(0, 0); events[0].Effectiveness != 0 || events[0].Damage != 0 {
+		t.Errorf("expected ground to be immune to electric: %+v", events[0])
+	}
+	if b.Opponent.Moves[0].Name != "struggle" {
+		t.Errorf("expected struggle for a pokemon without moves, got %+v", b.Opponent.Moves)
+	}
+
+	// Aynı tohumla aynı savaş aynı şekilde biter
+	play := func() (battleOutcome, int, int) {
+		b := newBattle(42, []string{"normal", "flying"}, tackle, quickAttack)
+		outcome := runBattle(b, func(string) (string, error) { return "1", nil })
+		return outcome, b.Player.HP, b.Round
+	}
+	outcome, hp, rounds := play()
+	if outcome != battleWon {
+		t.Errorf("expected pikachu to win, got %v", outcome)
+	}
+	if o, h, r := play(); o != outcome || h != hp || r != rounds {
+		t.Errorf("expected a reproducible battle, got %v/%d/%d and %v/%d/%d", outcome, hp, rounds, o, h, r)
+	}
+
+	inputs := []string{"thunderbolt", "catch"}
+	b = newBattle(1, []string{"normal"}, tackle)
+	if outcome := runBattle(b, func(string) (string, error) {
+		line := inputs[0]
+		inputs = inputs[1:]
+		return line, nil
+	}); outcome != battleLeft || b.Round != 0 {
+		t.Errorf("expected to leave the battle without playing a turn, got %v after %d rounds", outcome, b.Round)
+	}
+	if outcome := runBattle(newBattle(1, []string{"normal"}, tackle), func(string) (string, error) { return "", io.EOF }); outcome != battleRan {
+		t.Errorf("expected EOF to run away, got %v", outcome)
+	}
+}
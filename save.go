@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/savefile"
+)
+
+var saveData = savefile.New()
+var savePath string
+
+func dataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "share", "pokedexcli")
+	}
+	return ""
+}
+
+func loadSave(path string) error {
+	f, err := readSave(path)
+	if err != nil {
+		return err
+	}
+	savePath = path
+	saveData = f
+	return nil
+}
+
+// readSave kaydı okur ama global durumu değiştirmez
+func readSave(path string) (*savefile.File, error) {
+	if path == "" {
+		return savefile.New(), nil
+	}
+	f, err := savefile.Load(path)
+	if errors.Is(err, savefile.ErrCorrupt) {
+		// Bozuk dosya yedeklendi, boş kayıtla devam ediyoruz
+		fmt.Println(err)
+		return f, nil
+	}
+	return f, err
+}
+
+func persist() error {
+	if savePath == "" {
+		return nil
+	}
+	if err := savefile.Save(savePath, saveData); err != nil {
+		return fmt.Errorf("could not save your progress: %w", err)
+	}
+	return nil
+}
+
+func checkSave(path string) error {
+	report, err := savefile.Check(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	fmt.Printf("Save file: %s\n", report.Path)
+	fmt.Printf("Version: %d (current is %d)\n", report.FromVersion, savefile.CurrentVersion)
+	if len(report.Steps) == 0 {
+		fmt.Println("No migrations needed.")
+	}
+	for _, step := range report.Steps {
+		fmt.Printf("  - v%d -> v%d: %s\n", step.From, step.From+1, step.Description)
+	}
+	fmt.Printf("Caught pokemon: %d\n", report.Caught)
+	return nil
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokecache"
+)
+
+type settings struct {
+	BaseURL   string `json:"base_url"`
+	CacheDir  string `json:"cache_dir"`
+	Offline   bool   `json:"offline"`
+	BundleDir string `json:"bundle_dir"`
+	// İlk istek dahil, geçici hatalarda en fazla kaç deneme yapılacağı
+	MaxAttempts int `json:"max_attempts"`
+	// PokeAPI'nin adil kullanım kuralı için istemci tarafı sınır, 0 kapatır
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+	// Tek bir HTTP denemesinin süresi, aşılırsa istek tekrar denenir. 0 sınırsız.
+	Timeout     duration `json:"timeout"`
+	SaveFile    string   `json:"save_file"`
+	HistoryFile string   `json:"history_file"`
+	// Oyun sürümü ya da sürüm grubu (red, scarlet-violet...), boşsa her oyun gösterilir
+	GameVersion string `json:"game_version"`
+	// Sadece komut satırından, kayıt dosyasını yazmadan kontrol eder
+	CheckSave bool   `json:"-"`
+	Profile   string `json:"-"`
+}
+
+// duration config dosyasında "10s" gibi yazılabilsin diye
+type duration time.Duration
+
+func (d duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+func defaultSettings(profile string) settings {
+	s := settings{
+		BaseURL:           pokeapi.DefaultBaseURL,
+		MaxAttempts:       3,
+		RequestsPerSecond: 5,
+		Burst:             10,
+		Timeout:           duration(15 * time.Second),
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		s.CacheDir = filepath.Join(dir, "pokedexcli")
+	}
+	s.Profile = profile
+	if dir := profileDir(profile); dir != "" {
+		s.SaveFile = filepath.Join(dir, "save.json")
+		s.HistoryFile = filepath.Join(dir, "history.txt")
+	} else {
+		s.HistoryFile = "pokedex_history.txt"
+	}
+	return s
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "config.json")
+}
+
+func loadSettings(args []string) (settings, error) {
+	return loadSettingsFor(args, "")
+}
+
+// Öncelik sırası: varsayılanlar < config dosyası < profil ayarları < ortam değişkenleri < flagler.
+// profile boşsa --profile, POKEDEX_PROFILE ya da en son kullanılan profil seçilir.
+// Genel config'teki, ortamdaki ve flaglerdeki kayıt ve geçmiş dosyaları sadece
+// default profile uygulanır. İsimli bir profilin dosyalarını yalnızca kendi
+// settings.json'ı taşıyabilir; yoksa profil nasıl açıldığına göre farklı
+// dosyalara yazar ya da her profil aynı dosyayı paylaşırdı.
+func loadSettingsFor(args []string, profile string) (settings, error) {
+	s := defaultSettings(defaultProfile)
+
+	fset := flag.NewFlagSet("pokedexcli", flag.ContinueOnError)
+	configPath := fset.String("config", defaultConfigPath(), "path to a JSON config file")
+	baseURL := fset.String("base-url", "", "PokeAPI base URL (env POKEDEX_BASE_URL)")
+	cacheDir := fset.String("cache-dir", "", "directory for the on-disk cache (env POKEDEX_CACHE_DIR)")
+	offline := fset.Bool("offline", false, "answer only from the cache and the data bundle (env POKEDEX_OFFLINE)")
+	bundleDir := fset.String("bundle", "", "directory with a local PokeAPI data bundle (env POKEDEX_BUNDLE_DIR)")
+	maxAttempts := fset.Int("max-attempts", 0, "attempts per request on transient failures (env POKEDEX_MAX_ATTEMPTS)")
+	rps := fset.Float64("rps", -1, "maximum PokeAPI requests per second, 0 disables (env POKEDEX_RPS)")
+	burst := fset.Int("burst", 0, "requests allowed in a burst (env POKEDEX_BURST)")
+	saveFile := fset.String("save", "", "path to the default profile's save file (env POKEDEX_SAVE_FILE)")
+	checkSave := fset.Bool("check", false, "check and dry-run migrate the save file, then exit without writing")
+	gameVersion := fset.String("game-version", "", "game version or version group to filter by, such as red or scarlet-violet (env POKEDEX_GAME_VERSION)")
+	profileFlag := fset.String("profile", "", "trainer profile to play as (env POKEDEX_PROFILE)")
+	timeout := fset.Duration("timeout", -1, "timeout for each network request attempt, 0 disables (env POKEDEX_TIMEOUT)")
+	if err := fset.Parse(args); err != nil {
+		return s, err
+	}
+
+	if profile == "" {
+		profile = *profileFlag
+	}
+	if profile == "" {
+		profile = os.Getenv("POKEDEX_PROFILE")
+	}
+	if profile == "" {
+		profile = readActiveProfile()
+	}
+	if err := validProfileName(profile); err != nil {
+		return s, err
+	}
+	s = defaultSettings(profile)
+	named := profile != defaultProfile
+
+	if *configPath != "" {
+		data, err := os.ReadFile(*configPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return s, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &s); err != nil {
+				return s, fmt.Errorf("error reading config %s: %w", *configPath, err)
+			}
+		}
+	}
+	if named {
+		defaults := defaultSettings(profile)
+		s.SaveFile, s.HistoryFile = defaults.SaveFile, defaults.HistoryFile
+	}
+
+	if dir := profileDir(profile); dir != "" {
+		profileSettings := filepath.Join(dir, "settings.json")
+		data, err := os.ReadFile(profileSettings)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return s, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &s); err != nil {
+				return s, fmt.Errorf("error reading profile settings %s: %w", profileSettings, err)
+			}
+		}
+	}
+
+	if v := os.Getenv("POKEDEX_BASE_URL"); v != "" {
+		s.BaseURL = v
+	}
+	if v := os.Getenv("POKEDEX_CACHE_DIR"); v != "" {
+		s.CacheDir = v
+	}
+	if v := os.Getenv("POKEDEX_OFFLINE"); v != "" {
+		s.Offline = v == "1" || strings.EqualFold(v, "true")
+	}
+	if v := os.Getenv("POKEDEX_BUNDLE_DIR"); v != "" {
+		s.BundleDir = v
+	}
+	if v := os.Getenv("POKEDEX_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid POKEDEX_MAX_ATTEMPTS %q", v)
+		}
+		s.MaxAttempts = n
+	}
+	if v := os.Getenv("POKEDEX_RPS"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid POKEDEX_RPS %q", v)
+		}
+		s.RequestsPerSecond = f
+	}
+	if v := os.Getenv("POKEDEX_BURST"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid POKEDEX_BURST %q", v)
+		}
+		s.Burst = n
+	}
+	if v := os.Getenv("POKEDEX_GAME_VERSION"); v != "" {
+		s.GameVersion = v
+	}
+	if v := os.Getenv("POKEDEX_SAVE_FILE"); v != "" && !named {
+		s.SaveFile = v
+	}
+	if v := os.Getenv("POKEDEX_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid POKEDEX_TIMEOUT %q", v)
+		}
+		s.Timeout = duration(d)
+	}
+
+	if *baseURL != "" {
+		s.BaseURL = *baseURL
+	}
+	if *cacheDir != "" {
+		s.CacheDir = *cacheDir
+	}
+	if *offline {
+		s.Offline = true
+	}
+	if *bundleDir != "" {
+		s.BundleDir = *bundleDir
+	}
+	if *maxAttempts > 0 {
+		s.MaxAttempts = *maxAttempts
+	}
+	if *rps >= 0 {
+		s.RequestsPerSecond = *rps
+	}
+	if *burst > 0 {
+		s.Burst = *burst
+	}
+	if *timeout >= 0 {
+		s.Timeout = duration(*timeout)
+	}
+	if *saveFile != "" && !named {
+		s.SaveFile = *saveFile
+	}
+	if *gameVersion != "" {
+		s.GameVersion = *gameVersion
+	}
+	s.CheckSave = *checkSave
+	return s, nil
+}
+
+func newCache(s settings) *pokecache.Cache {
+	limits := []pokecache.Option{
+		pokecache.WithMaxEntries(200),
+		pokecache.WithMaxBytes(32 << 20),
+	}
+	if s.CacheDir == "" {
+		return pokecache.NewCache(5*time.Second, limits...)
+	}
+	c, err := pokecache.NewDiskCache(5*time.Second, s.CacheDir, 24*time.Hour, limits...)
+	if err != nil {
+		fmt.Printf("disk cache disabled: %s\n", err)
+		return pokecache.NewCache(5*time.Second, limits...)
+	}
+	return c
+}
+
+// Şu an kullanılan ayarlar, profil değişince setup tarafından güncellenir
+var activeSettings settings
+
+func setup(s settings) {
+	activeSettings = s
+	cache = newCache(s)
+	client = pokeapi.NewClient(cache,
+		pokeapi.WithBaseURL(s.BaseURL),
+		pokeapi.WithOffline(s.Offline),
+		pokeapi.WithBundle(s.BundleDir),
+		pokeapi.WithRetry(s.MaxAttempts, 200*time.Millisecond, 5*time.Second),
+		pokeapi.WithRateLimit(s.RequestsPerSecond, s.Burst),
+		pokeapi.WithAttemptTimeout(s.Timeout.Duration()),
+		pokeapi.WithThrottleNotice(func(wait time.Duration) {
+			fmt.Printf("Throttled to respect PokeAPI limits, waiting %s...\n", wait.Round(time.Millisecond))
+		}),
+	)
+	if s.Offline {
+		fmt.Println("Offline mode: answering from the cache and the data bundle only.")
+	}
+	mapConfig.Next = client.LocationAreasURL()
+	mapConfig.Previous = ""
+	resetMapLevels()
+}
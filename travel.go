@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func commandGoto(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: goto <location-area>")
+	}
+	area, err := client.GetLocationArea(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if area.Name == saveData.Location {
+		fmt.Printf("You are already in %s.\n", area.Name)
+		return nil
+	}
+	if wild != nil {
+		fmt.Printf("You left the wild %s behind.\n", wild.Pokemon)
+		wild = nil
+	}
+	saveData.Location = area.Name
+	fmt.Printf("You traveled to %s.\n", area.Name)
+	return persist()
+}
+
+func commandWhere(ctx context.Context, cfg *config, args ...string) error {
+	if saveData.Location == "" {
+		return fmt.Errorf("You haven't gone anywhere yet. Use goto <location-area> to travel.")
+	}
+	fmt.Printf("Area: %s\n", saveData.Location)
+
+	area, err := client.GetLocationArea(ctx, saveData.Location)
+	if err != nil {
+		return err
+	}
+	if area.Location.Name == "" {
+		return nil
+	}
+	location, err := client.GetLocation(ctx, area.Location.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Location: %s\n", location.Name)
+	if location.Region.Name != "" {
+		fmt.Printf("Region: %s\n", location.Region.Name)
+	}
+
+	// PokeAPI komşuluk bilgisi vermiyor, aynı location'daki diğer alanları gösteriyoruz
+	fmt.Println("Nearby areas:")
+	nearby := 0
+	for _, other := range location.Areas {
+		if other.Name == area.Name {
+			continue
+		}
+		fmt.Printf("  - %s\n", other.Name)
+		nearby++
+	}
+	if nearby == 0 {
+		fmt.Println("  (none)")
+	}
+	if wild != nil {
+		fmt.Printf("A wild %s (Lv. %d) is in front of you.\n", wild.Pokemon, wild.Level)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ardamertdedeoglu/pokedexcli/internal/pokeapi"
+)
+
+// gameVersion oturum boyunca kullanılan sürüm filtresi. "red" gibi tek bir
+// sürüm ya da "scarlet-violet" gibi bir sürüm grubu olabilir.
+type gameVersion struct {
+	Name string
+	// Karşılaşma ve game_indices verisindeki sürüm adları
+	Versions []string
+	// Hareketler ve sprite'lar sürüm grubuna göre
+	Group string
+}
+
+func (v *gameVersion) has(version string) bool {
+	return slices.Contains(v.Versions, version)
+}
+
+// activeSettings.GameVersion'ın çözülmüş hali
+var resolvedVersion *gameVersion
+
+func resolveVersion(ctx context.Context, name string) (*gameVersion, error) {
+	version, err := client.GetVersion(ctx, name)
+	if err == nil {
+		return &gameVersion{Name: version.Name, Versions: []string{version.Name}, Group: version.VersionGroup.Name}, nil
+	}
+	if errors.Is(err, pokeapi.ErrOffline) {
+		// Bundle'da sürüm verisi yoksa en azından karşılaşmalar filtrelensin
+		return &gameVersion{Name: name, Versions: []string{name}}, nil
+	}
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return nil, err
+	}
+
+	group, err := client.GetVersionGroup(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return nil, fmt.Errorf("Unknown game version %q.", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	v := &gameVersion{Name: group.Name, Group: group.Name}
+	for _, version := range group.Versions {
+		v.Versions = append(v.Versions, version.Name)
+	}
+	return v, nil
+}
+
+// currentVersion ayarlı sürümü döner, sürüm seçilmemişse nil
+func currentVersion(ctx context.Context) (*gameVersion, error) {
+	name := activeSettings.GameVersion
+	if name == "" {
+		return nil, nil
+	}
+	if resolvedVersion != nil && resolvedVersion.Name == name {
+		return resolvedVersion, nil
+	}
+	v, err := resolveVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	resolvedVersion = v
+	return v, nil
+}
+
+// pickEncounterVersion bölgedeki sürümlerden filtreye uyan ilkini seçer
+func pickEncounterVersion(area pokeapi.LocationArea, v *gameVersion) (string, error) {
+	versions := encounterVersions(area)
+	if len(versions) == 0 {
+		return "", fmt.Errorf("No wild pokemon appear in %s.", area.Name)
+	}
+	if v == nil {
+		return versions[0], nil
+	}
+	for _, version := range versions {
+		if v.has(version) {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("%s doesn't exist in pokemon %s.", area.Name, v.Name)
+}
+
+// areaPokemon bölgede görülen pokemonları döner, v verilmişse sadece o sürümdekileri
+func areaPokemon(area pokeapi.LocationArea, v *gameVersion) []string {
+	var names []string
+	for _, enc := range area.PokemonEncounters {
+		for _, vd := range enc.VersionDetails {
+			if v == nil || v.has(vd.Version.Name) {
+				names = append(names, enc.Pokemon.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func pokemonInVersion(p pokeapi.Pokemon, v *gameVersion) bool {
+	for _, index := range p.GameIndices {
+		if v.has(index.Version.Name) {
+			return true
+		}
+	}
+	// Yeni oyunlarda game_indices boş, hareket verisine bakıyoruz
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if v.Group != "" && detail.VersionGroup.Name == v.Group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type learnedMove struct {
+	Name   string
+	Method string
+	Level  int
+}
+
+func learnset(p pokeapi.Pokemon, group string) []learnedMove {
+	var moves []learnedMove
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != group {
+				continue
+			}
+			moves = append(moves, learnedMove{
+				Name:   move.Move.Name,
+				Method: detail.MoveLearnMethod.Name,
+				Level:  detail.LevelLearnedAt,
+			})
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].Method != moves[j].Method {
+			return moves[i].Method < moves[j].Method
+		}
+		if moves[i].Level != moves[j].Level {
+			return moves[i].Level < moves[j].Level
+		}
+		return moves[i].Name < moves[j].Name
+	})
+	return moves
+}
+
+func commandVersion(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		v, err := currentVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			fmt.Println("No game version selected, showing data from every game.")
+			return nil
+		}
+		fmt.Printf("Playing pokemon %s (%s).\n", v.Name, strings.Join(v.Versions, ", "))
+		return nil
+	}
+	if args[0] == "all" {
+		activeSettings.GameVersion = ""
+		resolvedVersion = nil
+		fmt.Println("Showing data from every game.")
+		return nil
+	}
+
+	v, err := resolveVersion(ctx, strings.ToLower(args[0]))
+	if err != nil {
+		return err
+	}
+	activeSettings.GameVersion = v.Name
+	resolvedVersion = v
+	fmt.Printf("Now playing pokemon %s (%s).\n", v.Name, strings.Join(v.Versions, ", "))
+	if wild != nil && !v.has(wild.Version) {
+		fmt.Printf("The wild %s from pokemon %s fled.\n", wild.Pokemon, wild.Version)
+		wild = nil
+	}
+	return nil
+}